@@ -0,0 +1,69 @@
+package common
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+)
+
+const (
+	CsvExtension       = ".csv"
+	TsvExtension       = ".tsv"
+	csvFilePermissions = 0600
+	utf8Bom            = "\uFEFF"
+)
+
+// Csv Same as Xlsx, but as plain text, so translations can be reviewed in diffs.
+// The separator is a comma for .csv files, and a tab for .tsv files.
+type Csv struct {
+	Path  Path
+	Comma rune
+}
+
+func (c *Csv) GetData(locales []Locale) (KeyLocaleValueMap, []TableProblem, error) {
+	fileContent, err := os.ReadFile(string(c.Path))
+	if err != nil {
+		return nil, nil, err
+	}
+	// Excel adds a byte order mark when saving as UTF-8 CSV.
+	fileContent = bytes.TrimPrefix(fileContent, []byte(utf8Bom))
+
+	reader := csv.NewReader(bytes.NewReader(fileContent))
+	reader.Comma = c.Comma
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return getDataFromRows(rows, locales)
+}
+
+func (c *Csv) EnsureExists(sourceLocale Locale, nonSourceLocales []Locale) error {
+	_, err := os.Stat(string(c.Path))
+	if err == nil {
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	return c.Write(TranslationTable{
+		Translations:     KeyLocaleValueMap{},
+		SourceLocale:     sourceLocale,
+		NonSourceLocales: nonSourceLocales,
+	})
+}
+
+func (c *Csv) Write(table TranslationTable) error {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Comma = c.Comma
+
+	err := writer.WriteAll(getRowsFromData(table.getDisplayedTranslations(), table.SourceLocale, table.NonSourceLocales))
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(string(c.Path), buffer.Bytes(), csvFilePermissions)
+}
@@ -0,0 +1,39 @@
+package common
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCsv_WriteGetData(t *testing.T) {
+	for _, comma := range []rune{',', '\t'} {
+		csvFile := Csv{Path: Path(filepath.Join(t.TempDir(), "translations.csv")), Comma: comma}
+
+		err := csvFile.Write(TranslationTable{
+			Translations: KeyLocaleValueMap{
+				"key1": {"en": "value, \"1\"", "fr": "valeur\n1"},
+				"key2": {"en": "value2"},
+			},
+			SourceLocale:     "en",
+			NonSourceLocales: []Locale{"fr"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, _, err := csvFile.GetData([]Locale{"en", "fr"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data["key1"]["en"] != "value, \"1\"" {
+			t.Error("Expected quoted source to be read back")
+		}
+		if data["key1"]["fr"] != "valeur\n1" {
+			t.Error("Expected multiline translation to be read back")
+		}
+		if value, ok := data["key2"]["fr"]; !ok || value != "" {
+			t.Error("Expected missing translation to be read back as empty")
+		}
+	}
+}
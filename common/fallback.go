@@ -0,0 +1,53 @@
+package common
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// LocaleFallbacks The locale each locale falls back to, for the messages it has no translation of its own for; e.g. "fr" for "fr-BE".
+// Chains end with the source locale, as Angular shows source strings when there is no translation.
+type LocaleFallbacks map[Locale]Locale
+
+// GetChain The locales a locale falls back to, in order; e.g. ["fr"] for "fr-BE".
+func (f LocaleFallbacks) GetChain(locale Locale) []Locale {
+	var chain []Locale
+
+	for fallback, ok := f[locale]; ok && !slices.Contains(chain, fallback); fallback, ok = f[fallback] {
+		chain = append(chain, fallback)
+	}
+
+	return chain
+}
+
+// Validate Checks that the fallbacks are between locales of the project, and do not loop.
+func (f LocaleFallbacks) Validate(sourceLocale Locale, nonSourceLocales []Locale) error {
+	for _, locale := range slices.Sorted(maps.Keys(f)) {
+		fallback := f[locale]
+		switch {
+		case !slices.Contains(nonSourceLocales, locale):
+			return fmt.Errorf("locale %s of fallback to %s is not a non-source locale of the project", strconv.Quote(string(locale)), strconv.Quote(string(fallback)))
+		case fallback != sourceLocale && !slices.Contains(nonSourceLocales, fallback):
+			return fmt.Errorf("fallback %s of locale %s is not a locale of the project", strconv.Quote(string(fallback)), strconv.Quote(string(locale)))
+		case slices.Contains(f.GetChain(fallback), locale) || fallback == locale:
+			return fmt.Errorf("fallback of locale %s loops back to it", strconv.Quote(string(locale)))
+		}
+	}
+
+	return nil
+}
+
+// Resolve The translation of a key in a locale, or else the one of the first locale of its chain with one,
+// along with the locale it comes from; nothing when none of them has one.
+// The source string is only returned when the source locale is explicitly in the chain.
+func (f LocaleFallbacks) Resolve(translations KeyLocaleValueMap, key Key, locale Locale) (Value, Locale) {
+	for _, chainLocale := range append([]Locale{locale}, f.GetChain(locale)...) {
+		if translation := translations[key][chainLocale]; translation != defaultTranslationValue {
+			return translation, chainLocale
+		}
+	}
+
+	return defaultTranslationValue, ""
+}
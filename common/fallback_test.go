@@ -0,0 +1,71 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLocaleFallbacks_Validate(t *testing.T) {
+	nonSourceLocales := []Locale{"fr", "fr-BE", "fr-CH"}
+
+	if err := (LocaleFallbacks{"fr-BE": "fr", "fr-CH": "fr-BE", "fr": "en"}).Validate("en", nonSourceLocales); err != nil {
+		t.Errorf("Expected fallbacks to be valid, got %v", err)
+	}
+	for _, fallbacks := range []LocaleFallbacks{
+		{"fr-BE": "nl"},
+		{"en": "fr"},
+		{"fr-BE": "fr", "fr": "fr-BE"},
+	} {
+		if err := fallbacks.Validate("en", nonSourceLocales); err == nil {
+			t.Errorf("Expected fallbacks %v to be refused", fallbacks)
+		}
+	}
+}
+
+func TestTranslationTable_GetStatus_Inherited(t *testing.T) {
+	table := TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": "Hello", "fr": "Bonjour", "fr-BE": "", "fr-CH": ""},
+			"key2": {"en": "Bye", "fr": "", "fr-BE": "", "fr-CH": "Adieu"},
+		},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"fr", "fr-BE", "fr-CH"},
+		Fallbacks:        LocaleFallbacks{"fr-BE": "fr", "fr-CH": "fr-BE"},
+	}
+
+	for _, expected := range []struct {
+		key    Key
+		locale Locale
+		status TranslationStatus
+	}{
+		{"key1", "fr-BE", StatusInherited},
+		{"key1", "fr-CH", StatusInherited},
+		{"key2", "fr-BE", StatusMissing},
+		{"key2", "fr-CH", StatusTranslated},
+	} {
+		if status := table.GetStatus(expected.key, expected.locale); status != expected.status {
+			t.Errorf("Expected status of %s in %s to be %d, got %d", expected.key, expected.locale, expected.status, status)
+		}
+	}
+}
+
+func TestTranslationManager_GetResolvedTranslationsByLocale(t *testing.T) {
+	translationManager := TranslationManager{}
+	translationManager.SetSourceLocale("en")
+	translationManager.EnsureLocale("fr")
+	translationManager.EnsureLocale("fr-BE")
+	_ = translationManager.AddTranslations(KeyValueMap{"key1": "Hello", "key2": "Bye", "key3": "Menu"}, "en")
+	_ = translationManager.AddTranslations(KeyValueMap{"key1": "Bonjour", "key2": "Au revoir"}, "fr")
+	_ = translationManager.AddTranslations(KeyValueMap{"key2": "À tantôt"}, "fr-BE")
+	if err := translationManager.SetFallbacks(LocaleFallbacks{"fr-BE": "fr"}); err != nil {
+		t.Fatal(err)
+	}
+
+	translations := translationManager.GetResolvedTranslationsByLocale()
+	if expected := (KeyValueMap{"key1": "Bonjour", "key2": "À tantôt", "key3": "Menu"}); !reflect.DeepEqual(translations["fr-BE"], expected) {
+		t.Errorf("Expected fr-BE to fall back to fr, and then to the source strings, got %v", translations["fr-BE"])
+	}
+	if translations["fr"]["key3"] != "" {
+		t.Errorf("Expected locales without fallback to be left as is, got %v", translations["fr"])
+	}
+}
@@ -2,7 +2,10 @@ module common
 
 go 1.24
 
-require github.com/xuri/excelize/v2 v2.9.0
+require (
+	github.com/xuri/excelize/v2 v2.9.0
+	golang.org/x/text v0.23.0
+)
 
 require (
 	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
@@ -12,5 +15,4 @@ require (
 	github.com/xuri/nfp v0.0.0-20250226145837-86d5fc24b2ba // indirect
 	golang.org/x/crypto v0.36.0 // indirect
 	golang.org/x/net v0.38.0 // indirect
-	golang.org/x/text v0.23.0 // indirect
 )
@@ -8,7 +8,7 @@ import {open} from "node:fs/promises";
 
 const wasi = new WASI({
     version: "preview1",
-    args: argv,
+    args: argv.slice(1), // Drop the node executable, so the script is seen as the program name.
     env,
     preopens: {
         "/": process.cwd(),
@@ -0,0 +1,159 @@
+package common
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	reportFilePermissions = 0600
+)
+
+// Severity How much an issue matters; errors make the run fail.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Rules Identify the kind of an issue, for tools to group and filter them.
+const (
+	RulePlaceholderCount     = "placeholder-count"
+	RuleMissingPlaceholder   = "missing-placeholder"
+	RuleUnknownPlaceholder   = "unknown-placeholder"
+	RuleElementPlaceholders  = "element-placeholders"
+	RuleAmbiguousPlaceholder = "ambiguous-placeholder"
+	RuleNormalization        = "normalization"
+	RuleMaxLength            = "max-length"
+	RuleWhitespace           = "whitespace"
+	RuleTrailingPunctuation  = "trailing-punctuation"
+	RuleUnbalancedTags       = "unbalanced-tags"
+	RuleDoubledWords         = "doubled-words"
+	RuleIdenticalToSource    = "identical-to-source"
+	RuleEditedKey            = "edited-key"
+	RuleEditedSource         = "edited-source"
+	RuleTranslationsFile     = "translations-file"
+	RuleLocale               = "locale"
+	RuleMergeConflict        = "merge-conflict"
+)
+
+// ruleDescriptions What each rule checks, for tools showing them.
+var ruleDescriptions = map[string]string{
+	RulePlaceholderCount:     "The translation has as many placeholders as the source string.",
+	RuleMissingPlaceholder:   "The translation has all the placeholders of the source string.",
+	RuleUnknownPlaceholder:   "The translation has no placeholder that is not in the source string.",
+	RuleElementPlaceholders:  "The opening and closing element placeholders of the translation are balanced, and nested as in HTML.",
+	RuleAmbiguousPlaceholder: "The placeholders shown as their text in the translations file can be told apart.",
+	RuleNormalization:        "The translation is normalized; e.g. its characters are composed, and it has no zero-width characters.",
+	RuleMaxLength:            "The translation is not longer than the maximum length of the message.",
+	RuleWhitespace:           "The translation has the same leading and trailing whitespaces as the source string.",
+	RuleTrailingPunctuation:  "The translation ends with the same punctuation mark as the source string.",
+	RuleUnbalancedTags:       "The HTML-like tags of the translation are all closed, in order.",
+	RuleDoubledWords:         "The translation has no word repeated by mistake.",
+	RuleIdenticalToSource:    "The translation differs from the source string, which may be left untranslated.",
+	RuleEditedKey:            "The keys of the translations file are not edited.",
+	RuleEditedSource:         "The source strings of the translations file are not edited.",
+	RuleTranslationsFile:     "The translations file is well-formed.",
+	RuleLocale:               "The locales are known BCP 47 language tags, written the same in angular.json, the xlf files, and the translations file.",
+	RuleMergeConflict:        "The translations of a returned per-locale workbook were not also changed in the translations file since it was written.",
+}
+
+// Issue Something found wrong while running, about a translation, or the translations file.
+type Issue struct {
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Key      Key      `json:"key,omitempty"`
+	Locale   Locale   `json:"locale,omitempty"`
+	Source   Value    `json:"source,omitempty"`
+	Target   Value    `json:"target,omitempty"`
+	Message  string   `json:"message"`
+	// File The file the issue is in, with the line of the key, if any; e.g. the xlf file of the locale.
+	File Path `json:"file,omitempty"`
+	Line int  `json:"line,omitempty"`
+}
+
+// The message of the issue, with the key and locale it is about, if any.
+func (i Issue) getSummary() string {
+	var location []string
+	if i.Key != "" {
+		location = append(location, "key "+strconv.Quote(string(i.Key)))
+	}
+	if i.Locale != "" {
+		location = append(location, "locale "+strconv.Quote(string(i.Locale)))
+	}
+	if len(location) == 0 {
+		return i.Message
+	}
+
+	return strings.Join(location, ", ") + ": " + i.Message
+}
+
+// LocaleChanges How the translations of a locale changed since the previous run.
+type LocaleChanges struct {
+	Added     int `json:"added"`
+	Updated   int `json:"updated"`
+	Removed   int `json:"removed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// Report Everything found during a run, meant for CI dashboards and the like.
+type Report struct {
+	Issues  []Issue                  `json:"issues"`
+	Changes map[Locale]LocaleChanges `json:"changes"`
+}
+
+// GetLocaleChanges Compares the translations of a locale to the previous ones; empty translations count as missing.
+func GetLocaleChanges(previousTranslations KeyValueMap, translations KeyValueMap) LocaleChanges {
+	var changes LocaleChanges
+
+	for key, translation := range translations {
+		previousTranslation := previousTranslations[key]
+		switch {
+		case translation == previousTranslation:
+			if translation != defaultTranslationValue {
+				changes.Unchanged++
+			}
+		case previousTranslation == defaultTranslationValue:
+			changes.Added++
+		case translation == defaultTranslationValue:
+			changes.Removed++
+		default:
+			changes.Updated++
+		}
+	}
+	for key, previousTranslation := range previousTranslations {
+		if _, ok := translations[key]; !ok && previousTranslation != defaultTranslationValue {
+			changes.Removed++
+		}
+	}
+
+	return changes
+}
+
+// HasErrors Whether any of the issues is an error.
+func (r Report) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r Report) Write(path Path) error {
+	if r.Issues == nil {
+		r.Issues = []Issue{}
+	}
+
+	bytes, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(string(path), append(bytes, '\n'), reportFilePermissions)
+}
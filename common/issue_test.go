@@ -0,0 +1,111 @@
+package common
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetLocaleChanges(t *testing.T) {
+	changes := GetLocaleChanges(
+		KeyValueMap{"key1": "valeur1", "key2": "valeur2", "key3": "", "key4": "valeur4", "key5": "valeur5"},
+		KeyValueMap{"key1": "valeur1", "key2": "autre valeur2", "key3": "valeur3", "key4": "", "key6": ""},
+	)
+
+	expectedChanges := LocaleChanges{Added: 1, Updated: 1, Removed: 2, Unchanged: 1}
+	if changes != expectedChanges {
+		t.Errorf("Expected %+v, got %+v", expectedChanges, changes)
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+	path := Path(filepath.Join(t.TempDir(), "report.json"))
+
+	err := Report{Changes: map[Locale]LocaleChanges{"fr": {Added: 1}}}.Write(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileContent, err := os.ReadFile(string(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report map[string]any
+	err = json.Unmarshal(fileContent, &report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues, ok := report["issues"].([]any); !ok || len(issues) != 0 {
+		t.Errorf("Expected issues to be an empty list, got %v", report["issues"])
+	}
+}
+
+func TestReport_WriteSarif(t *testing.T) {
+	path := Path(filepath.Join(t.TempDir(), "report.sarif"))
+	report := Report{Issues: []Issue{
+		{Severity: SeverityWarning, Rule: RuleMissingPlaceholder, Key: "key1", Locale: "fr", Message: "message", File: "messages.fr.xlf", Line: 12},
+	}}
+
+	err := report.WriteSarif(path, "1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileContent, err := os.ReadFile(string(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sarif sarifLog
+	err = json.Unmarshal(fileContent, &sarif)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := sarif.Runs[0]
+	if len(run.Tool.Driver.Rules) != 1 || run.Tool.Driver.Rules[0].ID != RuleMissingPlaceholder {
+		t.Errorf("Expected the rule of the issue, got %v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 1 || run.Results[0].Level != "warning" || run.Results[0].Locations[0].PhysicalLocation.Region.StartLine != 12 {
+		t.Errorf("Expected a warning at line 12, got %+v", run.Results)
+	}
+}
+
+func TestReport_WriteJUnit(t *testing.T) {
+	path := Path(filepath.Join(t.TempDir(), "report.xml"))
+	report := Report{Issues: []Issue{
+		{Severity: SeverityError, Rule: RuleMaxLength, Key: "key1", Locale: "fr", Message: "message"},
+		{Severity: SeverityWarning, Rule: RuleMissingPlaceholder, Key: "key1", Locale: "de", Message: "message"},
+		{Severity: SeverityError, Rule: RuleLocale, Locale: "xx", Message: "message"},
+	}}
+
+	err := report.WriteJUnit(path, []Locale{"de", "fr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileContent, err := os.ReadFile(string(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var testSuites junitTestSuites
+	err = xml.Unmarshal(fileContent, &testSuites)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := testSuites.TestSuites[0].TestCases
+	if len(testCases) != 3 || testCases[0].Name != "de" || testCases[1].Name != "fr" || testCases[2].Name != "xx" {
+		t.Fatalf("Expected a test case per locale, including the ones that are not listed, got %+v", testCases)
+	}
+	if testCases[0].Failure != nil || testCases[0].SystemOut == "" {
+		t.Error("Expected warnings not to fail the test case of their locale")
+	}
+	if testCases[1].Failure == nil {
+		t.Error("Expected errors to fail the test case of their locale")
+	}
+	if testCases[2].Failure == nil || testSuites.TestSuites[0].Failures != 2 {
+		t.Error("Expected errors of locales that are not listed, e.g. the source locale, to fail their test case")
+	}
+}
@@ -0,0 +1,107 @@
+package common
+
+import (
+	"encoding/xml"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	junitTestSuiteName = toolName
+	junitClassName     = "translations"
+	// junitTranslationsFileTestCaseName The test case of the issues about the translations file, rather than a locale.
+	junitTranslationsFileTestCaseName = "translations file"
+)
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit Writes the issues as JUnit XML, with a test case per locale.
+// Locales with issues that are not listed get a test case too, after the listed ones; e.g. the source locale.
+// A locale fails when any of its issues is an error; warnings are only written as the output of the test case.
+func (r Report) WriteJUnit(path Path, locales []Locale) error {
+	testSuite := junitTestSuite{Name: junitTestSuiteName}
+
+	names := []string{}
+	for _, locale := range locales {
+		names = append(names, string(locale))
+	}
+	hasTranslationsFileIssues := false
+	for _, issue := range r.Issues {
+		hasTranslationsFileIssues = hasTranslationsFileIssues || issue.Locale == ""
+		if issue.Locale != "" && !slices.Contains(names, string(issue.Locale)) {
+			names = append(names, string(issue.Locale))
+		}
+	}
+	if hasTranslationsFileIssues {
+		names = append(names, junitTranslationsFileTestCaseName)
+	}
+
+	for _, name := range names {
+		testCase := junitTestCase{Name: name, ClassName: junitClassName}
+
+		var errorLines, warningLines []string
+		for _, issue := range r.Issues {
+			if string(issue.Locale) != name && (issue.Locale != "" || name != junitTranslationsFileTestCaseName) {
+				continue
+			}
+
+			line := string(issue.Severity) + " [" + issue.Rule + "] " + issue.getSummary()
+			if issue.File != "" {
+				location := string(issue.File)
+				if issue.Line > 0 {
+					location += ":" + strconv.Itoa(issue.Line)
+				}
+				line += " (" + location + ")"
+			}
+			if issue.Severity == SeverityError {
+				errorLines = append(errorLines, line)
+			} else {
+				warningLines = append(warningLines, line)
+			}
+		}
+
+		if len(errorLines) > 0 {
+			testCase.Failure = &junitFailure{
+				Message: strconv.Itoa(len(errorLines)) + " errors",
+				Type:    string(SeverityError),
+				Text:    strings.Join(errorLines, "\n"),
+			}
+			testSuite.Failures++
+		}
+		testCase.SystemOut = strings.Join(warningLines, "\n")
+		testSuite.TestCases = append(testSuite.TestCases, testCase)
+	}
+	testSuite.Tests = len(testSuite.TestCases)
+
+	bytes, err := xml.MarshalIndent(junitTestSuites{TestSuites: []junitTestSuite{testSuite}}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(string(path), append([]byte(xml.Header), append(bytes, '\n')...), reportFilePermissions)
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/text/language"
+)
+
+// ParseLocale Parses a locale as a BCP 47 language tag, and returns it in its canonical form; e.g. "pt-BR" for "pt_br".
+// Returns an error if the locale is not well-formed, or if its language or region is unknown to CLDR,
+// as Angular would have no locale data for it.
+func ParseLocale(locale Locale) (Locale, error) {
+	tag, err := language.Parse(string(locale))
+	if err != nil {
+		var valueError language.ValueError
+		if errors.As(err, &valueError) {
+			return "", fmt.Errorf("unknown subtag %s", strconv.Quote(valueError.Subtag()))
+		}
+		return "", errors.New("not a well-formed BCP 47 language tag")
+	}
+
+	base, confidence := tag.Base()
+	if confidence != language.Exact {
+		return "", errors.New("undetermined language")
+	}
+	if base.IsPrivateUse() {
+		return "", fmt.Errorf("unknown language %s", strconv.Quote(base.String()))
+	}
+	region, confidence := tag.Region()
+	if confidence == language.Exact && region.IsPrivateUse() {
+		return "", fmt.Errorf("unknown region %s", strconv.Quote(region.String()))
+	}
+
+	return Locale(tag.String()), nil
+}
+
+// ValidateLocales Checks the locales of the project are known BCP 47 language tags, written in their canonical form,
+// and that no two of them are the same locale written differently; e.g. "fr-BE" and "fr_be".
+func ValidateLocales(sourceLocale Locale, nonSourceLocales []Locale) []Issue {
+	var issues []Issue
+
+	localesByCanonical := map[Locale]Locale{}
+	for _, locale := range append([]Locale{sourceLocale}, nonSourceLocales...) {
+		canonicalLocale, err := ParseLocale(locale)
+		if err != nil {
+			issues = append(issues, Issue{
+				Severity: SeverityError,
+				Rule:     RuleLocale,
+				Locale:   locale,
+				Message:  fmt.Sprintf("invalid locale: %s", err),
+			})
+			continue
+		}
+
+		if otherLocale, ok := localesByCanonical[canonicalLocale]; ok {
+			issues = append(issues, Issue{
+				Severity: SeverityError,
+				Rule:     RuleLocale,
+				Locale:   locale,
+				Message:  fmt.Sprintf("same locale as %s, written differently", strconv.Quote(string(otherLocale))),
+			})
+			continue
+		}
+		localesByCanonical[canonicalLocale] = locale
+
+		if canonicalLocale != locale {
+			issues = append(issues, Issue{
+				Severity: SeverityWarning,
+				Rule:     RuleLocale,
+				Locale:   locale,
+				Message:  fmt.Sprintf("locale is not in its canonical form; use %s instead", strconv.Quote(string(canonicalLocale))),
+			})
+		}
+	}
+
+	return issues
+}
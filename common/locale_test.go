@@ -0,0 +1,46 @@
+package common
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseLocale(t *testing.T) {
+	for _, testCase := range []struct {
+		locale         Locale
+		expectedLocale Locale
+		isValid        bool
+	}{
+		{"fr-BE", "fr-BE", true},
+		{"pt-br", "pt-BR", true},
+		{"nl_BE", "nl-BE", true},
+		{"zh-hant-tw", "zh-Hant-TW", true},
+		{"es-419", "es-419", true},
+		{"iw", "he", true},
+		{"xx", "", false},
+		{"fr-XX", "", false},
+		{"und", "", false},
+		{"english", "", false},
+	} {
+		locale, err := ParseLocale(testCase.locale)
+		if (err == nil) != testCase.isValid || locale != testCase.expectedLocale {
+			t.Errorf("Expected %q to parse as %q (valid: %v), got %q (%v)", testCase.locale, testCase.expectedLocale, testCase.isValid, locale, err)
+		}
+	}
+}
+
+func TestValidateLocales(t *testing.T) {
+	if issues := ValidateLocales("en", []Locale{"fr", "fr-BE"}); len(issues) != 0 {
+		t.Errorf("Expected no issue, got %v", issues)
+	}
+
+	issues := ValidateLocales("en", []Locale{"de-de", "en-US", "fr-BE", "fr_BE", "xx"})
+	var severities []Severity
+	for _, issue := range issues {
+		severities = append(severities, issue.Severity)
+	}
+	expectedSeverities := []Severity{SeverityWarning, SeverityError, SeverityError}
+	if !slices.Equal(severities, expectedSeverities) || issues[0].Locale != "de-de" || issues[1].Locale != "fr_BE" || issues[2].Locale != "xx" {
+		t.Errorf("Expected non-canonical, duplicate, and unknown locales to be reported, got %v", issues)
+	}
+}
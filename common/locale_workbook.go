@@ -0,0 +1,174 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// GetLocaleWorkbookPath The path of the workbook of a single locale, next to the translations file; e.g. "translations.fr.xlsx".
+func GetLocaleWorkbookPath(path Path, locale Locale) Path {
+	extension := filepath.Ext(string(path))
+	base := strings.TrimSuffix(string(path), extension)
+
+	return Path(base + "." + string(locale) + XlsxExtension)
+}
+
+// WriteLocaleWorkbooks Writes a workbook per non-source locale, to be sent to the translator responsible for it.
+// Each workbook has a single sheet, with the key, source, translation, and notes columns.
+func WriteLocaleWorkbooks(path Path, table TranslationTable) ([]Path, error) {
+	var paths []Path
+
+	for _, locale := range table.NonSourceLocales {
+		localeTable := table
+		localeTable.NonSourceLocales = []Locale{locale}
+
+		xlsxFile := Xlsx{Path: GetLocaleWorkbookPath(path, locale), SheetPerLocale: true}
+		err := xlsxFile.Write(localeTable)
+		if err != nil {
+			return nil, err
+		}
+		err = recordSplitTranslations(xlsxFile.Path, localeTable, locale)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, xlsxFile.Path)
+	}
+
+	return paths, nil
+}
+
+// Record the translations as written in the workbook of a locale, so merging it back only takes what the translator changed.
+func recordSplitTranslations(path Path, table TranslationTable, locale Locale) error {
+	workbook, err := excelize.OpenFile(string(path))
+	if err != nil {
+		return err
+	}
+	defer workbook.Close()
+
+	metadata, err := readMetadata(workbook)
+	if err != nil {
+		return err
+	}
+	maps.DeleteFunc(metadata, func(name string, _ string) bool { return strings.HasPrefix(name, metadataSplitTranslationPrefix) })
+	for key, localeValueMap := range table.getDisplayedTranslations() {
+		metadata[metadataSplitTranslationPrefix+string(key)] = string(localeValueMap[locale])
+	}
+	err = writeMetadata(workbook, metadata)
+	if err != nil {
+		return err
+	}
+
+	return workbook.Save()
+}
+
+// ReadLocaleWorkbook Reads a workbook returned by a translator.
+// Only the translation column may have been edited; the workbook is refused if anything else changed,
+// or if its source strings are not the current ones anymore.
+// Placeholders shown as their text are parsed if the workbook was written with readable placeholders, whatever the table says;
+// texts that could stand for several placeholders are returned as issues.
+// Only the translations changed by the translator are returned. The ones also changed in the translations file since the workbook was written
+// are not, so they do not overwrite newer translations; they are returned as issues instead.
+func ReadLocaleWorkbook(path Path, table TranslationTable) (Locale, KeyValueMap, []Issue, error) {
+	workbook, err := excelize.OpenFile(string(path))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	defer workbook.Close()
+
+	// Workbooks written by older versions have no translations recorded, so all their translations are taken.
+	metadata, err := readMetadata(workbook)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	hasSplitTranslations := slices.ContainsFunc(slices.Collect(maps.Keys(metadata)), func(name string) bool {
+		return strings.HasPrefix(name, metadataSplitTranslationPrefix)
+	})
+	table.ReadablePlaceholders = metadata[metadataReadablePlaceholders] == strconv.FormatBool(true)
+
+	rows, err := getSheetRows(workbook, workbook.GetSheetName(0))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if len(rows) == 0 || len(rows[0]) < 3 {
+		return "", nil, nil, fmt.Errorf("%s: missing header", path)
+	}
+
+	header := rows[0]
+	locale := Locale(header[2])
+	if header[0] != keyColumnLabel || Locale(header[1]) != table.SourceLocale {
+		return "", nil, nil, fmt.Errorf("%s: expected header to start with %s and %s, got %v",
+			path, strconv.Quote(keyColumnLabel), strconv.Quote(string(table.SourceLocale)), header)
+	}
+	if !slices.Contains(table.NonSourceLocales, locale) {
+		return "", nil, nil, fmt.Errorf("%s: unknown locale %s", path, strconv.Quote(string(locale)))
+	}
+
+	displayedTranslations := table.getDisplayedTranslations()
+	keyValueMap := KeyValueMap{}
+	var issues []Issue
+	var problems []error
+	for i, row := range rows[1:] {
+		if isBlankRow(row) {
+			continue
+		}
+		// Trailing empty cells are not returned.
+		row = append(row, make([]string, max(0, 4-len(row)))...)
+		key, source, value, notes := Key(row[0]), Value(row[1]), Value(row[2]), row[3]
+		rowNumber := i + 2
+
+		localeValueMap, ok := displayedTranslations[key]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Errorf("row %d: unknown key %s", rowNumber, strconv.Quote(string(key))))
+		case source != localeValueMap[table.SourceLocale]:
+			problems = append(problems, fmt.Errorf("row %d: source of %s was changed, or is outdated; expected %s, got %s",
+				rowNumber, strconv.Quote(string(key)), strconv.Quote(string(localeValueMap[table.SourceLocale])), strconv.Quote(string(source))))
+		case notes != table.MessageInfos[key].GetNotes():
+			problems = append(problems, fmt.Errorf("row %d: notes of %s were changed", rowNumber, strconv.Quote(string(key))))
+		case !hasSplitTranslations:
+			keyValueMap[key] = value
+		case value == Value(metadata[metadataSplitTranslationPrefix+string(key)]):
+			// Left as is by the translator, so a newer translation in the translations file is kept.
+		case localeValueMap[locale] != Value(metadata[metadataSplitTranslationPrefix+string(key)]) && localeValueMap[locale] != value:
+			issues = append(issues, Issue{
+				Severity: SeverityWarning,
+				Rule:     RuleMergeConflict,
+				Key:      key,
+				Locale:   locale,
+				Source:   source,
+				Target:   value,
+				Message: fmt.Sprintf("translation was also changed in the translations file since the workbook was written, to %s, which is kept; merge them by hand",
+					strconv.Quote(string(localeValueMap[locale]))),
+				File: path,
+			})
+		default:
+			keyValueMap[key] = value
+		}
+	}
+	if len(problems) > 0 {
+		return "", nil, nil, fmt.Errorf("%s: refusing workbook, as only the %s column may be edited:\n%w",
+			path, strconv.Quote(string(locale)), errors.Join(problems...))
+	}
+
+	if table.ReadablePlaceholders {
+		keyLocaleValueMap := KeyLocaleValueMap{}
+		for key, value := range keyValueMap {
+			keyLocaleValueMap[key] = LocaleValueMap{locale: value}
+		}
+		keyLocaleValueMap, placeholderIssues := ParseReadablePlaceholders(keyLocaleValueMap, table.Translations.GroupByLocale()[table.SourceLocale], table.MessageInfos)
+		for i := range placeholderIssues {
+			placeholderIssues[i].File = path
+		}
+		issues = append(issues, placeholderIssues...)
+		keyValueMap = keyLocaleValueMap.GroupByLocale()[locale]
+	}
+
+	return locale, keyValueMap, issues, nil
+}
@@ -0,0 +1,156 @@
+package common
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func getLocaleWorkbookTestTable() TranslationTable {
+	return TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": "value1", "fr": "", "de": ""},
+			"key2": {"en": "value2", "fr": "", "de": ""},
+		},
+		MessageInfos: KeyMessageInfoMap{
+			"key1": {Description: "description"},
+		},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"de", "fr"},
+	}
+}
+
+func TestGetLocaleWorkbookPath(t *testing.T) {
+	if path := GetLocaleWorkbookPath("./translations.csv", "fr"); path != "./translations.fr.xlsx" {
+		t.Errorf("Expected path %s, got %s", "./translations.fr.xlsx", path)
+	}
+}
+
+func TestReadLocaleWorkbook(t *testing.T) {
+	table := getLocaleWorkbookTestTable()
+	paths, err := WriteLocaleWorkbooks(Path(filepath.Join(t.TempDir(), "translations.xlsx")), table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err := excelize.OpenFile(string(paths[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.SetCellValue("fr", "C2", "valeur1")
+	err = workbook.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.Close()
+
+	locale, keyValueMap, issues, err := ReadLocaleWorkbook(paths[1], table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if locale != "fr" {
+		t.Errorf("Expected locale %s, got %s", "fr", locale)
+	}
+	if keyValueMap["key1"] != "valeur1" || len(keyValueMap) != 1 || len(issues) != 0 {
+		t.Errorf("Expected only the changed translation to be read, got %v and %v", keyValueMap, issues)
+	}
+}
+
+func TestReadLocaleWorkbook_Conflict(t *testing.T) {
+	table := getLocaleWorkbookTestTable()
+	table.Translations["key2"]["fr"] = "valeur2"
+	paths, err := WriteLocaleWorkbooks(Path(filepath.Join(t.TempDir(), "translations.xlsx")), table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err := excelize.OpenFile(string(paths[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.SetCellValue("fr", "C2", "valeur1")
+	_ = workbook.SetCellValue("fr", "C3", "valeur2 du traducteur")
+	err = workbook.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.Close()
+
+	// Both translations are changed in the translations file while the workbook is away.
+	table.Translations["key1"]["fr"] = "valeur1"
+	table.Translations["key2"]["fr"] = "nouvelle valeur2"
+	_, keyValueMap, issues, err := ReadLocaleWorkbook(paths[1], table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if keyValueMap["key1"] != "valeur1" {
+		t.Error("Expected the same change to be merged")
+	}
+	if _, ok := keyValueMap["key2"]; ok || len(issues) != 1 || issues[0].Rule != RuleMergeConflict || issues[0].Key != "key2" {
+		t.Errorf("Expected conflicting translation to be reported, not merged, got %v and %v", keyValueMap, issues)
+	}
+}
+
+func TestReadLocaleWorkbook_EditedSource(t *testing.T) {
+	table := getLocaleWorkbookTestTable()
+	paths, err := WriteLocaleWorkbooks(Path(filepath.Join(t.TempDir(), "translations.xlsx")), table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err := excelize.OpenFile(string(paths[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.SetCellValue("de", "B3", "edited")
+	_ = workbook.SetCellValue("de", "A4", "key3")
+	err = workbook.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.Close()
+
+	_, _, _, err = ReadLocaleWorkbook(paths[0], table)
+	if err == nil {
+		t.Fatal("Expected workbook to be refused")
+	}
+	if !strings.Contains(err.Error(), "row 3") || !strings.Contains(err.Error(), "row 4") {
+		t.Errorf("Expected every problem to be reported, got %s", err)
+	}
+}
+
+func TestReadLocaleWorkbook_ReadablePlaceholders(t *testing.T) {
+	table := getLocaleWorkbookTestTable()
+	table.Translations["key1"]["en"] = "Click ${{START_BOLD_TEXT}}here${{CLOSE_BOLD_TEXT}}"
+	table.MessageInfos["key1"] = MessageInfo{PlaceholderTexts: map[string]string{"START_BOLD_TEXT": "<b>", "CLOSE_BOLD_TEXT": "</b>"}}
+	table.ReadablePlaceholders = true
+	paths, err := WriteLocaleWorkbooks(Path(filepath.Join(t.TempDir(), "translations.xlsx")), table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err := excelize.OpenFile(string(paths[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.SetCellValue("fr", "C2", "Cliquez <b>ici</b>")
+	err = workbook.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.Close()
+
+	// Merged back by a run without readable placeholders.
+	table.ReadablePlaceholders = false
+	_, keyValueMap, issues, err := ReadLocaleWorkbook(paths[1], table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyValueMap["key1"] != "Cliquez ${{START_BOLD_TEXT}}ici${{CLOSE_BOLD_TEXT}}" || len(issues) != 0 {
+		t.Errorf("Expected placeholders to be read back as written, got %v and %v", keyValueMap, issues)
+	}
+}
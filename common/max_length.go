@@ -0,0 +1,46 @@
+package common
+
+import (
+	"regexp"
+	"strconv"
+	"unicode/utf16"
+)
+
+const (
+	// maxLengthInDescriptionRegex How developers declare a maximum length in the description of a message; e.g. "Submit button, maxLength: 12".
+	maxLengthInDescriptionRegex = `(?i)\bmax-?length\s*[:=]\s*(\d+)`
+)
+
+// GetMaxLengthFromDescription The maximum length declared in the description of a message, or 0 if there is none.
+func GetMaxLengthFromDescription(description string) int {
+	regex := regexp.MustCompile(maxLengthInDescriptionRegex)
+	match := regex.FindStringSubmatch(description)
+	if match == nil {
+		return 0
+	}
+
+	maxLength, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+
+	return maxLength
+}
+
+// GetLength The number of characters of a value, placeholders excluded, as they are replaced at runtime.
+// Characters are counted in UTF-16 code units, as by JavaScript and Excel's LEN, so an emoji counts for 2.
+func GetLength(value Value) int {
+	regex := regexp.MustCompile(PlaceholderInTextRegex)
+
+	length := 0
+	for _, r := range regex.ReplaceAllString(string(value), "") {
+		length += utf16.RuneLen(r)
+	}
+
+	return length
+}
+
+// ExceedsMaxLength Whether a value is longer than the given maximum length, if any.
+func ExceedsMaxLength(value Value, maxLength int) bool {
+	return maxLength > 0 && GetLength(value) > maxLength
+}
@@ -0,0 +1,24 @@
+package common
+
+import "testing"
+
+func TestGetMaxLengthFromDescription(t *testing.T) {
+	for description, expectedMaxLength := range map[string]int{
+		"Submit button, maxLength: 12": 12,
+		"SMS max-length=160":           160,
+		"Submit button":                0,
+	} {
+		if maxLength := GetMaxLengthFromDescription(description); maxLength != expectedMaxLength {
+			t.Errorf("Expected max length of %q to be %d, got %d", description, expectedMaxLength, maxLength)
+		}
+	}
+}
+
+func TestGetLength(t *testing.T) {
+	if length := GetLength("Hé ${{NAME}}!"); length != 4 {
+		t.Errorf("Expected placeholders to be excluded, got %d", length)
+	}
+	if length := GetLength("Hé 👋"); length != 5 {
+		t.Errorf("Expected characters outside the BMP to count as UTF-16 code units, got %d", length)
+	}
+}
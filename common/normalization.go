@@ -0,0 +1,272 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Steps of the normalization of translations, in the order they are applied.
+const (
+	// NormalizationControlCharacters Line breaks pasted from Excel ("\r\n") become "\n", tabs become spaces, and other control characters are removed.
+	NormalizationControlCharacters = "control-characters"
+	// NormalizationZeroWidth Invisible characters are removed; e.g. zero-width spaces and byte order marks.
+	// Zero-width joiners and non-joiners are kept, as some scripts and emojis need them.
+	NormalizationZeroWidth = "zero-width"
+	// NormalizationNfc Characters are composed, so "é" is always the same character, whatever the keyboard it was typed with.
+	NormalizationNfc = "nfc"
+	// NormalizationQuotes Double quotes are replaced by the ones of the locale, when configured; e.g. «» for French.
+	NormalizationQuotes = "quotes"
+	// NormalizationFrenchSpacing Spaces before ":", ";", "!", "?", and "»", and after "«", become non-breaking in French,
+	// so the punctuation mark never starts a line.
+	NormalizationFrenchSpacing = "french-spacing"
+)
+
+var normalizationSteps = []string{
+	NormalizationControlCharacters,
+	NormalizationZeroWidth,
+	NormalizationNfc,
+	NormalizationQuotes,
+	NormalizationFrenchSpacing,
+}
+
+// Steps changing what translations say, rather than invisible differences, so they are only applied when enabled.
+var optInNormalizationSteps = []string{
+	NormalizationQuotes,
+	NormalizationFrenchSpacing,
+}
+
+// Named quote styles, as opening and closing quotes.
+var quoteStyles = map[string]string{
+	"straight": `""`,
+	"english":  "“”",
+	"german":   "„“",
+	"french":   "«»",
+}
+
+// The double quotes replaced by the ones of the locale.
+var doubleQuotes = []rune{'"', '“', '”', '„', '‟', '«', '»'}
+
+// Zero-width space, word joiner, and byte order mark.
+var zeroWidthCharacters = []rune{'\u200B', '\u2060', '\uFEFF'}
+
+// NormalizationConfig How translations are normalized for a project; the zero value only applies the steps removing invisible differences,
+// not the ones changing quotes or spacing.
+// Source strings only get the whitespace policy applied, as they come from the templates.
+type NormalizationConfig struct {
+	Enabled  []string `json:"enabled"`  // Opt-in steps to apply; e.g. french-spacing.
+	Disabled []string `json:"disabled"` // Steps not to apply.
+	// Quotes The quotes of each locale, by locale or language; either a named style ("straight", "english", "german", or "french"),
+	// or the opening and closing quotes; e.g. "‹›".
+	Quotes     map[Locale]string `json:"quotes"`
+	Whitespace WhitespaceConfig  `json:"whitespace"`
+}
+
+// Normalizer Cleans up translations, as typed or pasted by translators.
+type Normalizer struct {
+	Config NormalizationConfig
+}
+
+// Normalization A translation changed by the normalizer.
+type Normalization struct {
+	Key      Key
+	Locale   Locale
+	IsSource bool // Whether the source string was changed, rather than a translation.
+	Before   Value
+	After    Value
+	Steps    []string // The steps that changed the translation.
+}
+
+// NewNormalizer Returns a normalizer configured for the project.
+func NewNormalizer(config NormalizationConfig) (Normalizer, error) {
+	for _, step := range config.Disabled {
+		if !slices.Contains(normalizationSteps, step) {
+			return Normalizer{}, fmt.Errorf("unknown normalization step %s", strconv.Quote(step))
+		}
+	}
+	for _, step := range config.Enabled {
+		if !slices.Contains(normalizationSteps, step) {
+			return Normalizer{}, fmt.Errorf("unknown normalization step %s", strconv.Quote(step))
+		}
+		if !slices.Contains(optInNormalizationSteps, step) {
+			return Normalizer{}, fmt.Errorf("normalization step %s is applied unless disabled, so it cannot be enabled", strconv.Quote(step))
+		}
+	}
+	for locale, quotes := range config.Quotes {
+		if _, ok := quoteStyles[quotes]; !ok && len([]rune(quotes)) != 2 {
+			return Normalizer{}, fmt.Errorf("quotes of locale %s must be a named style, or an opening and a closing quote; got %s",
+				strconv.Quote(string(locale)), strconv.Quote(quotes))
+		}
+	}
+
+	err := config.Whitespace.validate()
+	if err != nil {
+		return Normalizer{}, err
+	}
+
+	return Normalizer{Config: config}, nil
+}
+
+// Normalize Applies the enabled steps to the translation of a key in a locale, and then the whitespace policy of the key.
+// Returns the normalized translation, and the steps that changed it, if any.
+func (n Normalizer) Normalize(key Key, value Value, locale Locale) (Value, []string) {
+	var changedSteps []string
+
+	for _, step := range normalizationSteps {
+		if slices.Contains(n.Config.Disabled, step) || (slices.Contains(optInNormalizationSteps, step) && !slices.Contains(n.Config.Enabled, step)) {
+			continue
+		}
+
+		var normalizedValue Value
+		switch step {
+		case NormalizationControlCharacters:
+			normalizedValue = normalizeControlCharacters(value, n.Config.Whitespace.getPolicy(key))
+		case NormalizationZeroWidth:
+			normalizedValue = Value(strings.Map(func(r rune) rune {
+				if slices.Contains(zeroWidthCharacters, r) {
+					return -1
+				}
+				return r
+			}, string(value)))
+		case NormalizationNfc:
+			normalizedValue = Value(norm.NFC.String(string(value)))
+		case NormalizationQuotes:
+			normalizedValue = n.normalizeQuotes(value, locale)
+		case NormalizationFrenchSpacing:
+			normalizedValue = normalizeFrenchSpacing(value, locale)
+		}
+
+		if normalizedValue != value {
+			changedSteps = append(changedSteps, step)
+			value = normalizedValue
+		}
+	}
+
+	normalizedValue, steps := n.NormalizeSource(key, value)
+
+	return normalizedValue, append(changedSteps, steps...)
+}
+
+// GetNewNormalizations The normalizations not found by the previous run, given the source strings it had; nil if there was none.
+// Source strings are kept as is in the xlf files, so their normalizations are found again on every run, until the source string changes.
+func GetNewNormalizations(normalizations []Normalization, previousSourceStrings KeyValueMap) []Normalization {
+	return slices.DeleteFunc(slices.Clone(normalizations), func(normalization Normalization) bool {
+		previousSourceString, ok := previousSourceStrings[normalization.Key]
+		return normalization.IsSource && ok && previousSourceString == normalization.Before
+	})
+}
+
+// NormalizeSource Applies the whitespace policy of a key to its source string.
+// Returns the normalized source string, and the whitespace step if it changed it.
+func (n Normalizer) NormalizeSource(key Key, value Value) (Value, []string) {
+	normalizedValue := applyWhitespacePolicy(value, n.Config.Whitespace.getPolicy(key))
+	if normalizedValue == value {
+		return value, nil
+	}
+
+	return normalizedValue, []string{NormalizationWhitespace}
+}
+
+// Line breaks and tabs are whitespaces, so they are kept as is for keys whose whitespaces are preserved, as in their source strings.
+func normalizeControlCharacters(value Value, policy WhitespacePolicy) Value {
+	valueStr := string(value)
+	keptCharacters := "\n"
+	if policy == WhitespacePreserve {
+		keptCharacters = "\r\n\t"
+	} else {
+		valueStr = strings.NewReplacer("\r\n", "\n", "\r", "\n", "\t", " ").Replace(valueStr)
+	}
+
+	return Value(strings.Map(func(r rune) rune {
+		if !strings.ContainsRune(keptCharacters, r) && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, valueStr))
+}
+
+// The opening and closing quotes of a locale, if configured for it, or for its language.
+func (n Normalizer) getQuotes(locale Locale) ([]rune, bool) {
+	quotes, ok := n.Config.Quotes[locale]
+	if !ok {
+		base, _ := language.Make(string(locale)).Base()
+		quotes, ok = n.Config.Quotes[Locale(base.String())]
+	}
+	if !ok {
+		return nil, false
+	}
+	if style, ok := quoteStyles[quotes]; ok {
+		quotes = style
+	}
+
+	return []rune(quotes), true
+}
+
+// Replace the double quotes of a translation by the ones of the locale, alternating between opening and closing quotes.
+// Translations with an odd number of double quotes are left as is, as they cannot be paired.
+// Quotes inside HTML-like tags and placeholders are not text, and are kept.
+func (n Normalizer) normalizeQuotes(value Value, locale Locale) Value {
+	quotes, ok := n.getQuotes(locale)
+	if !ok {
+		return value
+	}
+
+	// Text between HTML-like tags and placeholders, which are kept as is.
+	valueStr := string(value)
+	var texts, kept []string
+	end := 0
+	for _, indexes := range regexp.MustCompile(htmlTagRegex+`|`+PlaceholderInTextRegex).FindAllStringIndex(valueStr, -1) {
+		texts = append(texts, valueStr[end:indexes[0]])
+		kept = append(kept, valueStr[indexes[0]:indexes[1]])
+		end = indexes[1]
+	}
+	texts = append(texts, valueStr[end:])
+
+	quoteCount := 0
+	for _, text := range texts {
+		quoteCount += len(slices.DeleteFunc([]rune(text), func(r rune) bool { return !slices.Contains(doubleQuotes, r) }))
+	}
+	if quoteCount == 0 || quoteCount%2 != 0 {
+		return value
+	}
+
+	var builder strings.Builder
+	isOpen := false
+	for i, text := range texts {
+		for _, r := range text {
+			if slices.Contains(doubleQuotes, r) {
+				r = quotes[0]
+				if isOpen {
+					r = quotes[1]
+				}
+				isOpen = !isOpen
+			}
+			builder.WriteRune(r)
+		}
+		if i < len(kept) {
+			builder.WriteString(kept[i])
+		}
+	}
+
+	return Value(builder.String())
+}
+
+// Make the spaces around French punctuation marks non-breaking; e.g. "Attention : " becomes "Attention\u00A0: ".
+// Missing spaces are not added, as the punctuation mark may not be one; e.g. in "12:30" or "https://".
+func normalizeFrenchSpacing(value Value, locale Locale) Value {
+	base, _ := language.Make(string(locale)).Base()
+	if base.String() != "fr" {
+		return value
+	}
+
+	valueStr := regexp.MustCompile(` ([:;!?»])`).ReplaceAllString(string(value), "\u00A0$1")
+	valueStr = strings.ReplaceAll(valueStr, "« ", "«\u00A0")
+
+	return Value(valueStr)
+}
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizer_Normalize(t *testing.T) {
+	normalizer, err := NewNormalizer(NormalizationConfig{
+		Enabled: []string{NormalizationQuotes, NormalizationFrenchSpacing},
+		Quotes:  map[Locale]string{"fr": "french", "de": "„“"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		value         Value
+		locale        Locale
+		expectedValue Value
+		expectedSteps []string
+	}{
+		{"Line 1\r\nLine\t2", "en", "Line 1\nLine 2", []string{NormalizationControlCharacters}},
+		{"Zero\u200Bwidth\uFEFF", "en", "Zerowidth", []string{NormalizationZeroWidth}},
+		{"Cafe\u0301", "en", "Caf\u00E9", []string{NormalizationNfc}},
+		{`Say "hi"`, "en", `Say "hi"`, nil},
+		{`Sag "hallo" <a href="x">`, "de-CH", `Sag „hallo“ <a href="x">`, []string{NormalizationQuotes}},
+		{`Dis "salut`, "fr", `Dis "salut`, nil},
+		{`Attention : dis "salut" ! 12:30`, "fr-BE", "Attention\u00A0: dis «salut»\u00A0! 12:30", []string{NormalizationQuotes, NormalizationFrenchSpacing}},
+		{"Warning : ok", "en", "Warning : ok", nil},
+	} {
+		value, steps := normalizer.Normalize("key1", testCase.value, testCase.locale)
+		if value != testCase.expectedValue || !slices.Equal(steps, testCase.expectedSteps) {
+			t.Errorf("Expected %q in %s to be normalized to %q by %v, got %q by %v",
+				testCase.value, testCase.locale, testCase.expectedValue, testCase.expectedSteps, value, steps)
+		}
+	}
+}
+
+func TestNormalizer_Normalize_Default(t *testing.T) {
+	normalizer, err := NewNormalizer(NormalizationConfig{Quotes: map[Locale]string{"fr": "french"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value, _ := normalizer.Normalize("key1", `Attention : dis "salut"`, "fr"); value != `Attention : dis "salut"` {
+		t.Errorf("Expected opt-in steps not to be applied unless enabled, got %q", value)
+	}
+}
+
+func TestNormalizer_Normalize_Preserve(t *testing.T) {
+	normalizer, err := NewNormalizer(NormalizationConfig{Whitespace: WhitespaceConfig{Keys: map[Key]WhitespacePolicy{"key1": WhitespacePreserve}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value, _ := normalizer.Normalize("key1", "Line 1\r\n\tLine\u00072", "fr"); value != "Line 1\r\n\tLine2" {
+		t.Errorf("Expected line breaks and tabs to be preserved, and other control characters removed, got %q", value)
+	}
+	if value, _ := normalizer.Normalize("key2", "Line 1\r\nLine\t2", "fr"); value != "Line 1\nLine 2" {
+		t.Errorf("Expected line breaks and tabs of other keys to be normalized, got %q", value)
+	}
+}
+
+func TestNormalizer_Normalize_Disabled(t *testing.T) {
+	normalizer, err := NewNormalizer(NormalizationConfig{Disabled: []string{NormalizationNfc}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value, _ := normalizer.Normalize("key1", "Cafe\u0301", "fr"); value != "Cafe\u0301" {
+		t.Errorf("Expected disabled step not to be applied, got %q", value)
+	}
+}
+
+func TestNewNormalizer(t *testing.T) {
+	if _, err := NewNormalizer(NormalizationConfig{Disabled: []string{"unknown"}}); err == nil {
+		t.Error("Expected unknown step to be refused")
+	}
+	if _, err := NewNormalizer(NormalizationConfig{Enabled: []string{NormalizationNfc}}); err == nil {
+		t.Error("Expected step applied by default not to be enabled")
+	}
+	if _, err := NewNormalizer(NormalizationConfig{Quotes: map[Locale]string{"fr": "curly"}}); err == nil {
+		t.Error("Expected unknown quote style to be refused")
+	}
+}
+
+func TestTranslationManager_GetNormalizations(t *testing.T) {
+	translationManager := TranslationManager{}
+	translationManager.SetSourceLocale("en")
+	_ = translationManager.AddTranslations(KeyValueMap{"key1": "Name:\t", "key2": "Send"}, "en")
+	_ = translationManager.AddTranslations(KeyValueMap{"key1": "Nom\u200B :", "key2": "Envoyer"}, "fr")
+
+	normalizations := translationManager.GetNormalizations()
+	if len(normalizations) != 1 || normalizations[0].Key != "key1" || normalizations[0].After != "Nom :" {
+		t.Errorf("Expected only the French translation to be normalized, got %v", normalizations)
+	}
+}
@@ -0,0 +1,304 @@
+package common
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	OdsExtension       = ".ods"
+	odsMimeType        = "application/vnd.oasis.opendocument.spreadsheet"
+	odsContentPath     = "content.xml"
+	odsFilePermissions = 0600
+	odsColumnWidth     = "9.5cm" // Roughly the same as columnWidth in Excel.
+
+	odsNamespaceOffice = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	odsNamespaceTable  = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	odsNamespaceText   = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+)
+
+const odsManifest = xml.Header + `<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
+ <manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + odsMimeType + `"/>
+ <manifest:file-entry manifest:full-path="` + odsContentPath + `" manifest:media-type="text/xml"/>
+</manifest:manifest>
+`
+
+// Ods Same as Xlsx, but as an OpenDocument spreadsheet, for translators working with LibreOffice.
+type Ods struct {
+	Path Path
+}
+
+func (o *Ods) GetData(locales []Locale) (KeyLocaleValueMap, []TableProblem, error) {
+	archive, err := zip.OpenReader(string(o.Path))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer archive.Close()
+
+	content, err := archive.Open(odsContentPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer content.Close()
+
+	rows, err := readOdsRows(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return getDataFromRows(rows, locales)
+}
+
+func (o *Ods) EnsureExists(sourceLocale Locale, nonSourceLocales []Locale) error {
+	_, err := os.Stat(string(o.Path))
+	if err == nil {
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	return o.Write(TranslationTable{
+		Translations:     KeyLocaleValueMap{},
+		SourceLocale:     sourceLocale,
+		NonSourceLocales: nonSourceLocales,
+	})
+}
+
+func (o *Ods) Write(table TranslationTable) error {
+	rows := getRowsFromData(table.getDisplayedTranslations(), table.SourceLocale, table.NonSourceLocales)
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+
+	// The mimetype must be the first file of the archive, and must not be compressed.
+	mimeType, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	_, err = mimeType.Write([]byte(odsMimeType))
+	if err != nil {
+		return err
+	}
+
+	manifest, err := archive.Create("META-INF/manifest.xml")
+	if err != nil {
+		return err
+	}
+	_, err = manifest.Write([]byte(odsManifest))
+	if err != nil {
+		return err
+	}
+
+	content, err := archive.Create(odsContentPath)
+	if err != nil {
+		return err
+	}
+	err = writeOdsContent(content, rows)
+	if err != nil {
+		return err
+	}
+
+	err = archive.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(string(o.Path), buffer.Bytes(), odsFilePermissions)
+}
+
+func writeOdsContent(writer io.Writer, rows [][]string) error {
+	var builder strings.Builder
+
+	builder.WriteString(xml.Header)
+	builder.WriteString(`<office:document-content` +
+		` xmlns:office="` + odsNamespaceOffice + `"` +
+		` xmlns:table="` + odsNamespaceTable + `"` +
+		` xmlns:text="` + odsNamespaceText + `"` +
+		` xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0"` +
+		` xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"` +
+		` office:version="1.2">`)
+	builder.WriteString(`<office:automatic-styles>`)
+	builder.WriteString(`<style:style style:name="co1" style:family="table-column">`)
+	builder.WriteString(`<style:table-column-properties style:column-width="` + odsColumnWidth + `"/>`)
+	builder.WriteString(`</style:style>`)
+	builder.WriteString(`</office:automatic-styles>`)
+	builder.WriteString(`<office:body><office:spreadsheet>`)
+	builder.WriteString(`<table:table table:name="` + defaultSheetName + `">`)
+	builder.WriteString(`<table:table-column table:style-name="co1" table:number-columns-repeated="` + strconv.Itoa(len(rows[0])) + `"/>`)
+
+	for _, row := range rows {
+		builder.WriteString(`<table:table-row>`)
+		for _, cell := range row {
+			if cell == "" {
+				builder.WriteString(`<table:table-cell/>`)
+				continue
+			}
+
+			builder.WriteString(`<table:table-cell office:value-type="string">`)
+			// Each line is a paragraph of its own.
+			for _, line := range strings.Split(cell, "\n") {
+				builder.WriteString(`<text:p>`)
+				writeOdsText(&builder, line)
+				builder.WriteString(`</text:p>`)
+			}
+			builder.WriteString(`</table:table-cell>`)
+		}
+		builder.WriteString(`</table:table-row>`)
+	}
+
+	builder.WriteString(`</table:table>`)
+	builder.WriteString(`</office:spreadsheet></office:body>`)
+	builder.WriteString(`</office:document-content>`)
+
+	_, err := io.WriteString(writer, builder.String())
+
+	return err
+}
+
+// Write a line of text, with its whitespaces encoded, as they would otherwise be collapsed when read.
+// Single spaces between words are kept as is.
+func writeOdsText(builder *strings.Builder, line string) {
+	for len(line) > 0 {
+		index := strings.IndexAny(line, " \t")
+		if index == -1 {
+			_ = xml.EscapeText(builder, []byte(line))
+			return
+		}
+		_ = xml.EscapeText(builder, []byte(line[:index]))
+		line = line[index:]
+
+		if line[0] == '\t' {
+			builder.WriteString(`<text:tab/>`)
+			line = line[1:]
+			continue
+		}
+
+		spaces := len(line) - len(strings.TrimLeft(line, " "))
+		line = line[spaces:]
+		if spaces == 1 && index > 0 && len(line) > 0 {
+			builder.WriteString(" ")
+			continue
+		}
+		builder.WriteString(`<text:s text:c="` + strconv.Itoa(spaces) + `"/>`)
+	}
+}
+
+// Read the rows of the first table of an OpenDocument content file.
+func readOdsRows(reader io.Reader) ([][]string, error) {
+	var rows [][]string
+	var row []string
+	var cell strings.Builder
+	var rowRepeat, cellRepeat, paragraphs, pendingEmptyCells, pendingEmptyRows int
+	var inTable, inCell bool
+
+	decoder := xml.NewDecoder(reader)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			switch {
+			case token.Name.Space == odsNamespaceTable && token.Name.Local == "table":
+				inTable = true
+			case !inTable:
+				continue
+			case token.Name.Space == odsNamespaceTable && token.Name.Local == "table-row":
+				row = nil
+				rowRepeat = getOdsRepeat(token, "number-rows-repeated")
+				pendingEmptyCells = 0
+			case token.Name.Space == odsNamespaceTable && (token.Name.Local == "table-cell" || token.Name.Local == "covered-table-cell"):
+				cell.Reset()
+				cellRepeat = getOdsRepeat(token, "number-columns-repeated")
+				paragraphs = 0
+				inCell = true
+			case !inCell:
+				continue
+			case token.Name.Space == odsNamespaceOffice && token.Name.Local == "annotation":
+				// Comments are not part of the value of the cell.
+				err = decoder.Skip()
+				if err != nil {
+					return nil, err
+				}
+			case token.Name.Space == odsNamespaceText && token.Name.Local == "p":
+				if paragraphs > 0 {
+					cell.WriteString("\n")
+				}
+				paragraphs++
+			case token.Name.Space == odsNamespaceText && token.Name.Local == "s":
+				cell.WriteString(strings.Repeat(" ", getOdsRepeat(token, "c")))
+			case token.Name.Space == odsNamespaceText && token.Name.Local == "tab":
+				cell.WriteString("\t")
+			case token.Name.Space == odsNamespaceText && token.Name.Local == "line-break":
+				cell.WriteString("\n")
+			}
+		case xml.CharData:
+			if inCell && paragraphs > 0 {
+				cell.Write(token)
+			}
+		case xml.EndElement:
+			switch {
+			case token.Name.Space == odsNamespaceTable && token.Name.Local == "table":
+				// Only the first table is read.
+				return rows, nil
+			case token.Name.Space == odsNamespaceTable && (token.Name.Local == "table-cell" || token.Name.Local == "covered-table-cell"):
+				inCell = false
+				// Spreadsheets pad rows with lots of repeated empty cells.
+				if cell.Len() == 0 {
+					pendingEmptyCells += cellRepeat
+					continue
+				}
+				for range pendingEmptyCells {
+					row = append(row, "")
+				}
+				pendingEmptyCells = 0
+				for range cellRepeat {
+					row = append(row, cell.String())
+				}
+			case token.Name.Space == odsNamespaceTable && token.Name.Local == "table-row":
+				// Spreadsheets pad tables with lots of repeated empty rows.
+				if len(row) == 0 {
+					pendingEmptyRows += rowRepeat
+					continue
+				}
+				for range pendingEmptyRows {
+					rows = append(rows, []string{})
+				}
+				pendingEmptyRows = 0
+				for range rowRepeat {
+					rows = append(rows, row)
+				}
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func getOdsRepeat(element xml.StartElement, attributeName string) int {
+	for _, attribute := range element.Attr {
+		if attribute.Name.Local != attributeName {
+			continue
+		}
+		repeat, err := strconv.Atoi(attribute.Value)
+		if err != nil || repeat < 1 {
+			return 1
+		}
+
+		return repeat
+	}
+
+	return 1
+}
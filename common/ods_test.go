@@ -0,0 +1,63 @@
+package common
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOds_WriteGetData(t *testing.T) {
+	odsFile := Ods{Path: Path(filepath.Join(t.TempDir(), "translations.ods"))}
+
+	err := odsFile.Write(TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": " value  <1> & more ", "fr": "valeur\n\n1\tun"},
+			"key2": {"en": "value2"},
+		},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"fr"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, _, err := odsFile.GetData([]Locale{"en", "fr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data["key1"]["en"] != " value  <1> & more " {
+		t.Error("Expected spaces and escaped characters to be read back")
+	}
+	if data["key1"]["fr"] != "valeur\n\n1\tun" {
+		t.Error("Expected multiline translation to be read back")
+	}
+	if value, ok := data["key2"]["fr"]; !ok || value != "" {
+		t.Error("Expected missing translation to be read back as empty")
+	}
+}
+
+func TestReadOdsRows_Repeated(t *testing.T) {
+	content := `<office:document-content xmlns:office="` + odsNamespaceOffice + `" xmlns:table="` + odsNamespaceTable + `" xmlns:text="` + odsNamespaceText + `">
+<office:body><office:spreadsheet><table:table table:name="Sheet1">
+<table:table-row><table:table-cell><text:p>key</text:p></table:table-cell><table:table-cell><text:p>en</text:p></table:table-cell><table:table-cell table:number-columns-repeated="16382"/></table:table-row>
+<table:table-row><table:table-cell><text:p>key1</text:p></table:table-cell><table:table-cell><text:p><text:span>val</text:span>ue</text:p><office:annotation><text:p>comment</text:p></office:annotation></table:table-cell></table:table-row>
+<table:table-row table:number-rows-repeated="1048574"><table:table-cell table:number-columns-repeated="16384"/></table:table-row>
+</table:table><table:table table:name="Sheet2"><table:table-row><table:table-cell><text:p>other</text:p></table:table-cell></table:table-row></table:table>
+</office:spreadsheet></office:body></office:document-content>`
+
+	rows, err := readOdsRows(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if len(rows[0]) != 2 {
+		t.Error("Expected trailing empty cells to be dropped")
+	}
+	if rows[1][1] != "value" {
+		t.Error("Expected styled text to be read, without the comment")
+	}
+}
@@ -0,0 +1,273 @@
+package common
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	poExtension       = ".po"
+	poDirPermissions  = 0700
+	poFilePermissions = 0600
+)
+
+// Po Exchanges translations as gettext PO files, one per non-source locale, for translators working with Poedit and the like.
+// Each entry uses the key as msgctxt, the source string as msgid, and the translation as msgstr.
+type Po struct {
+	Dir Path
+}
+
+// poEntry A single entry of a PO file, without its comments.
+type poEntry struct {
+	msgctxt string
+	msgid   string
+	msgstr  string
+}
+
+func (p *Po) getLocalePath(locale Locale) Path {
+	return Path(filepath.Join(string(p.Dir), string(locale)+poExtension))
+}
+
+func (p *Po) GetData(locales []Locale) (KeyLocaleValueMap, []TableProblem, error) {
+	keyLocaleValueMap := KeyLocaleValueMap{}
+	var problems []TableProblem
+
+	paths, err := filepath.Glob(filepath.Join(string(p.Dir), "*"+poExtension))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, path := range paths {
+		locale, ok := matchLocaleHeader(strings.TrimSuffix(filepath.Base(path), poExtension), locales)
+		if !ok {
+			problems = append(problems, TableProblem{Sheet: filepath.Base(path), Message: "locale is not one of the project, file ignored"})
+			continue
+		}
+		if isWrittenDifferently(strings.TrimSuffix(filepath.Base(path), poExtension), locale) {
+			problems = append(problems, TableProblem{Sheet: filepath.Base(path), Message: "locale " + strconv.Quote(string(locale)) + " is written differently in angular.json, file read anyway; rename it"})
+		}
+
+		entries, err := readPoFile(Path(path))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, entry := range entries {
+			// The header entry has no context and an empty msgid.
+			if entry.msgctxt == "" {
+				continue
+			}
+
+			key := Key(entry.msgctxt)
+			if _, ok := keyLocaleValueMap[key]; !ok {
+				keyLocaleValueMap[key] = LocaleValueMap{}
+			}
+			keyLocaleValueMap[key][locale] = Value(entry.msgstr)
+		}
+	}
+
+	return keyLocaleValueMap, problems, nil
+}
+
+func (p *Po) EnsureExists(sourceLocale Locale, nonSourceLocales []Locale) error {
+	return os.MkdirAll(string(p.Dir), poDirPermissions)
+}
+
+func (p *Po) Write(table TranslationTable) error {
+	err := p.EnsureExists(table.SourceLocale, table.NonSourceLocales)
+	if err != nil {
+		return err
+	}
+
+	translationKeys := slices.Collect(maps.Keys(table.Translations))
+	sort.Slice(translationKeys, func(i, j int) bool {
+		return translationKeys[i] < translationKeys[j]
+	})
+
+	for _, locale := range table.NonSourceLocales {
+		var builder strings.Builder
+
+		// Write header.
+		builder.WriteString("msgid \"\"\n")
+		builder.WriteString("msgstr \"\"\n")
+		builder.WriteString(strconv.Quote("Content-Type: text/plain; charset=UTF-8\n") + "\n")
+		builder.WriteString(strconv.Quote(fmt.Sprintf("Language: %s\n", locale)) + "\n")
+		builder.WriteString(strconv.Quote(fmt.Sprintf("X-Source-Language: %s\n", table.SourceLocale)) + "\n")
+
+		// Write translations.
+		for _, key := range translationKeys {
+			builder.WriteString("\n")
+
+			messageInfo := table.MessageInfos[key]
+			if messageInfo.Description != "" {
+				writePoComment(&builder, "#.", messageInfo.Description)
+			}
+			if messageInfo.Meaning != "" {
+				writePoComment(&builder, "#.", "meaning: "+messageInfo.Meaning)
+			}
+			for _, location := range messageInfo.Locations {
+				writePoComment(&builder, "#:", location)
+			}
+
+			writePoString(&builder, "msgctxt", string(key))
+			writePoString(&builder, "msgid", string(table.Translations[key][table.SourceLocale]))
+			writePoString(&builder, "msgstr", string(table.Translations[key][locale]))
+		}
+
+		err = os.WriteFile(string(p.getLocalePath(locale)), []byte(builder.String()), poFilePermissions)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writePoComment(builder *strings.Builder, prefix string, comment string) {
+	for _, line := range strings.Split(comment, "\n") {
+		builder.WriteString(prefix + " " + line + "\n")
+	}
+}
+
+// Write a keyword followed by its quoted string.
+// Multiline strings are split after each line break, as gettext tools do.
+func writePoString(builder *strings.Builder, keyword string, str string) {
+	if !strings.Contains(strings.TrimSuffix(str, "\n"), "\n") {
+		builder.WriteString(keyword + " " + escapePoString(str) + "\n")
+		return
+	}
+
+	builder.WriteString(keyword + " \"\"\n")
+	for _, line := range strings.SplitAfter(str, "\n") {
+		if line == "" {
+			continue
+		}
+		builder.WriteString(escapePoString(line) + "\n")
+	}
+}
+
+func escapePoString(str string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\t", `\t`,
+		"\r", `\r`,
+	)
+
+	return `"` + replacer.Replace(str) + `"`
+}
+
+func unescapePoString(str string) (string, error) {
+	if len(str) < 2 || !strings.HasPrefix(str, `"`) || !strings.HasSuffix(str, `"`) {
+		return "", fmt.Errorf("invalid PO string %s", str)
+	}
+
+	var builder strings.Builder
+	str = str[1 : len(str)-1]
+	for i := 0; i < len(str); i++ {
+		if str[i] != '\\' {
+			builder.WriteByte(str[i])
+			continue
+		}
+
+		i++
+		if i >= len(str) {
+			return "", fmt.Errorf("invalid escape sequence at end of PO string %s", strconv.Quote(str))
+		}
+		switch str[i] {
+		case 'n':
+			builder.WriteByte('\n')
+		case 't':
+			builder.WriteByte('\t')
+		case 'r':
+			builder.WriteByte('\r')
+		default:
+			builder.WriteByte(str[i])
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func readPoFile(path Path) ([]poEntry, error) {
+	file, err := os.Open(string(path))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []poEntry
+	var entry poEntry
+	var hasEntry bool
+	var current *string
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		var keyword, rest string
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, `"`):
+			if current == nil {
+				return nil, fmt.Errorf("%s:%d: string continuation without keyword", path, lineNumber)
+			}
+			str, err := unescapePoString(line)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, lineNumber, err)
+			}
+			*current += str
+
+			continue
+		default:
+			keyword, rest, _ = strings.Cut(line, " ")
+		}
+
+		str, err := unescapePoString(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNumber, err)
+		}
+
+		switch keyword {
+		case "msgctxt":
+			// A context always starts a new entry.
+			if hasEntry {
+				entries = append(entries, entry)
+			}
+			entry = poEntry{msgctxt: str}
+			hasEntry = true
+			current = &entry.msgctxt
+		case "msgid":
+			// A msgid starts a new entry, unless it directly follows its context.
+			if hasEntry && current != &entry.msgctxt {
+				entries = append(entries, entry)
+				entry = poEntry{}
+			}
+			entry.msgid = str
+			hasEntry = true
+			current = &entry.msgid
+		case "msgstr":
+			entry.msgstr = str
+			current = &entry.msgstr
+		default:
+			return nil, fmt.Errorf("%s:%d: unsupported keyword %s", path, lineNumber, strconv.Quote(keyword))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if hasEntry {
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestPo_WriteGetData(t *testing.T) {
+	po := Po{Dir: Path(t.TempDir())}
+
+	err := po.Write(TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": "value1", "fr": "valeur1"},
+			"key2": {"en": "line1\nline2", "fr": "ligne \"1\"\nligne\t2"},
+		},
+		MessageInfos: KeyMessageInfoMap{
+			"key1": {Description: "description", Meaning: "meaning", Locations: []string{"src/app.html:1"}},
+		},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"fr"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, _, err := po.GetData([]Locale{"en", "fr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data["key1"]["fr"] != "valeur1" {
+		t.Error("Expected translation to be read back")
+	}
+	if data["key2"]["fr"] != "ligne \"1\"\nligne\t2" {
+		t.Error("Expected escaped multiline translation to be read back")
+	}
+	if _, ok := data["key1"]["en"]; ok {
+		t.Error("Expected no PO file for the source locale")
+	}
+}
+
+func TestPo_GetData_EmptyDir(t *testing.T) {
+	po := Po{Dir: Path(t.TempDir())}
+
+	data, _, err := po.GetData([]Locale{"en", "fr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(data) != 0 {
+		t.Error("Expected no translations")
+	}
+}
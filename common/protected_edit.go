@@ -0,0 +1,45 @@
+package common
+
+import (
+	"maps"
+	"slices"
+	"sort"
+)
+
+// ProtectedEdit A change made by translators to a key or a source string, which they are not meant to edit.
+type ProtectedEdit struct {
+	Key          Key
+	IsUnknownKey bool  // Whether the key did not exist when the store was last written, so it was edited or added.
+	Expected     Value // The source string, as it was last written; empty if the key is unknown.
+	Actual       Value // The source string found in the store.
+}
+
+// GetProtectedEdits Compares the data loaded from a store to the source strings as they were when the store was last written,
+// with the whitespace policy of their key applied, as the store has them.
+// Keys that did not exist back then were either edited or added by translators.
+func GetProtectedEdits(storedData KeyLocaleValueMap, sourceLocale Locale, previousSourceStrings KeyValueMap, normalizer Normalizer) []ProtectedEdit {
+	var protectedEdits []ProtectedEdit
+
+	keys := slices.Collect(maps.Keys(storedData))
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	for _, key := range keys {
+		actual, hasSource := storedData[key][sourceLocale]
+
+		expected, ok := previousSourceStrings[key]
+		if !ok {
+			protectedEdits = append(protectedEdits, ProtectedEdit{Key: key, IsUnknownKey: true, Actual: actual})
+			continue
+		}
+
+		// Stores without source strings cannot have them edited.
+		expected, _ = normalizer.NormalizeSource(key, expected)
+		if normalizedActual, _ := normalizer.NormalizeSource(key, actual); hasSource && normalizedActual != expected {
+			protectedEdits = append(protectedEdits, ProtectedEdit{Key: key, Expected: expected, Actual: actual})
+		}
+	}
+
+	return protectedEdits
+}
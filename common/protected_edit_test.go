@@ -0,0 +1,43 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetProtectedEdits(t *testing.T) {
+	normalizer, err := NewNormalizer(NormalizationConfig{Whitespace: WhitespaceConfig{
+		Keys: map[Key]WhitespacePolicy{"preserved": WhitespacePreserve, "trimmed": WhitespaceTrim},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	protectedEdits := GetProtectedEdits(KeyLocaleValueMap{
+		"key1":      {"en": "value1 ", "fr": "valeur1"},
+		"key2":      {"en": "edited", "fr": "valeur2"},
+		"key3":      {"fr": "valeur3"},
+		"empty":     {"en": "", "fr": ""},
+		"edited":    {"en": "value4", "fr": "valeur4"},
+		"preserved": {"en": "Total:", "fr": "Total :"},
+		"trimmed":   {"en": "a b", "fr": "a b"},
+	}, "en", KeyValueMap{
+		"key1":      "value1",
+		"key2":      "value2",
+		"key3":      "value3",
+		"key4":      "value4",
+		"empty":     "",
+		"preserved": "Total: ",
+		"trimmed":   " a  b",
+	}, normalizer)
+
+	expected := []ProtectedEdit{
+		{Key: "edited", IsUnknownKey: true, Actual: "value4"},
+		{Key: "key2", Expected: "value2", Actual: "edited"},
+		{Key: "preserved", Expected: "Total: ", Actual: "Total:"},
+		{Key: "trimmed", Expected: "a  b", Actual: "a b"},
+	}
+	if !reflect.DeepEqual(protectedEdits, expected) {
+		t.Errorf("Expected protected edits %v, got %v", expected, protectedEdits)
+	}
+}
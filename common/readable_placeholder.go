@@ -0,0 +1,143 @@
+package common
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The placeholders of a message that can be shown as their text, by ID; e.g. "{{ user.name }}" for INTERPOLATION.
+// A placeholder is not, when its text is empty, shared by other placeholders of the message,
+// or also in the source string as text, as it could not be told apart when read back.
+func (m MessageInfo) getReadablePlaceholders(source Value) map[string]string {
+	readablePlaceholders := map[string]string{}
+
+	sourceText := regexp.MustCompile(PlaceholderInTextRegex).ReplaceAllString(string(source), "")
+	for placeholderID, text := range m.PlaceholderTexts {
+		if text == "" || strings.Contains(text, "${{") || strings.Contains(sourceText, text) || len(m.getPlaceholderIDsOfText(text)) > 1 {
+			continue
+		}
+		readablePlaceholders[placeholderID] = text
+	}
+
+	return readablePlaceholders
+}
+
+// The placeholders of the message with the given text, sorted.
+func (m MessageInfo) getPlaceholderIDsOfText(text string) []string {
+	var placeholderIDs []string
+	for placeholderID, placeholderText := range m.PlaceholderTexts {
+		if placeholderText == text {
+			placeholderIDs = append(placeholderIDs, placeholderID)
+		}
+	}
+	slices.Sort(placeholderIDs)
+
+	return placeholderIDs
+}
+
+// Shows the placeholders of a value of the message as their text, when they can be told apart.
+func (m MessageInfo) toReadablePlaceholders(value Value, source Value) Value {
+	readablePlaceholders := m.getReadablePlaceholders(source)
+
+	regex := regexp.MustCompile(PlaceholderInTextRegex)
+	return Value(regex.ReplaceAllStringFunc(string(value), func(placeholder string) string {
+		if text, ok := readablePlaceholders[regex.FindStringSubmatch(placeholder)[1]]; ok {
+			return text
+		}
+		return placeholder
+	}))
+}
+
+// Turns back the text of the placeholders of a value of the message into placeholders.
+// Returns a message for each text that could stand for several placeholders, which is left as is.
+func (m MessageInfo) fromReadablePlaceholders(value Value, source Value) (Value, []string) {
+	var messages []string
+
+	readablePlaceholders := m.getReadablePlaceholders(source)
+	// Longer texts first, in case one contains another; e.g. "{{ a }}" and "{{ a }} b".
+	placeholderIDs := slices.SortedFunc(maps.Keys(readablePlaceholders), func(a string, b string) int {
+		return cmp.Compare(len(readablePlaceholders[b]), len(readablePlaceholders[a]))
+	})
+	valueStr := string(value)
+	for _, placeholderID := range placeholderIDs {
+		valueStr = strings.ReplaceAll(valueStr, readablePlaceholders[placeholderID], fmt.Sprintf(PlaceholderSprintf, placeholderID))
+	}
+
+	// Texts also in the source string as text are not placeholders.
+	sourceText := regexp.MustCompile(PlaceholderInTextRegex).ReplaceAllString(string(source), "")
+	for _, text := range slices.Compact(slices.Sorted(maps.Values(m.PlaceholderTexts))) {
+		placeholderIDs := m.getPlaceholderIDsOfText(text)
+		if text == "" || len(placeholderIDs) < 2 || strings.Count(valueStr, text) <= strings.Count(sourceText, text) {
+			continue
+		}
+		var placeholders []string
+		for _, placeholderID := range placeholderIDs {
+			placeholders = append(placeholders, fmt.Sprintf(PlaceholderSprintf, placeholderID))
+		}
+		messages = append(messages, fmt.Sprintf("%s could stand for any of the placeholders %s; use one of them instead",
+			strconv.Quote(text), strings.Join(placeholders, ", ")))
+	}
+
+	return Value(valueStr), messages
+}
+
+// The translations as shown to translators, with readable placeholders if enabled.
+func (t TranslationTable) getDisplayedTranslations() KeyLocaleValueMap {
+	if !t.ReadablePlaceholders {
+		return t.Translations
+	}
+
+	displayedTranslations := KeyLocaleValueMap{}
+	for key, localeValueMap := range t.Translations {
+		displayedTranslations[key] = LocaleValueMap{}
+		for locale, value := range localeValueMap {
+			displayedTranslations[key][locale] = t.MessageInfos[key].toReadablePlaceholders(value, localeValueMap[t.SourceLocale])
+		}
+	}
+
+	return displayedTranslations
+}
+
+// How a placeholder of a message is shown to translators.
+func (t TranslationTable) getDisplayedPlaceholder(key Key, placeholderID string) string {
+	if t.ReadablePlaceholders {
+		if text, ok := t.MessageInfos[key].getReadablePlaceholders(t.Translations[key][t.SourceLocale])[placeholderID]; ok {
+			return text
+		}
+	}
+
+	return fmt.Sprintf(PlaceholderSprintf, placeholderID)
+}
+
+// ParseReadablePlaceholders Turns back the placeholders shown as their text in the translations file into placeholders.
+// Texts that could stand for several placeholders of a message are left as is, and reported.
+func ParseReadablePlaceholders(data KeyLocaleValueMap, sourceStrings KeyValueMap, messageInfos KeyMessageInfoMap) (KeyLocaleValueMap, []Issue) {
+	var issues []Issue
+
+	parsedData := KeyLocaleValueMap{}
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		parsedData[key] = LocaleValueMap{}
+		for _, locale := range slices.Sorted(maps.Keys(data[key])) {
+			value, messages := messageInfos[key].fromReadablePlaceholders(data[key][locale], sourceStrings[key])
+			parsedData[key][locale] = value
+			for _, message := range messages {
+				issues = append(issues, Issue{
+					Severity: SeverityWarning,
+					Rule:     RuleAmbiguousPlaceholder,
+					Key:      key,
+					Locale:   locale,
+					Source:   sourceStrings[key],
+					Target:   data[key][locale],
+					Message:  message,
+				})
+			}
+		}
+	}
+
+	return parsedData, issues
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTranslationTable_getDisplayedTranslations(t *testing.T) {
+	table := TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": "Hi ${{START_TAG_SPAN}}${{NAME}}${{CLOSE_TAG_SPAN}} ${{START_TAG_SPAN_1}}!${{CLOSE_TAG_SPAN}}", "fr": "Salut ${{START_TAG_SPAN}}${{NAME}}${{CLOSE_TAG_SPAN}}"},
+			"key2": {"en": "Type {{ name }} for ${{NAME}}"},
+		},
+		MessageInfos: KeyMessageInfoMap{
+			"key1": {PlaceholderTexts: map[string]string{"NAME": "{{ name }}", "START_TAG_SPAN": "<span>", "START_TAG_SPAN_1": "<span>", "CLOSE_TAG_SPAN": "</span>"}},
+			"key2": {PlaceholderTexts: map[string]string{"NAME": "{{ name }}"}},
+		},
+		SourceLocale:         "en",
+		NonSourceLocales:     []Locale{"fr"},
+		ReadablePlaceholders: true,
+	}
+
+	displayedTranslations := table.getDisplayedTranslations()
+	// Placeholders with the same text, or with a text also in the source string, are shown as their ID.
+	for _, expected := range []struct {
+		key    Key
+		locale Locale
+		value  Value
+	}{
+		{"key1", "en", "Hi ${{START_TAG_SPAN}}{{ name }}</span> ${{START_TAG_SPAN_1}}!</span>"},
+		{"key1", "fr", "Salut ${{START_TAG_SPAN}}{{ name }}</span>"},
+		{"key2", "en", "Type {{ name }} for ${{NAME}}"},
+	} {
+		if value := displayedTranslations[expected.key][expected.locale]; value != expected.value {
+			t.Errorf("Expected %s in %s to be shown as %q, got %q", expected.key, expected.locale, expected.value, value)
+		}
+	}
+
+	data, issues := ParseReadablePlaceholders(displayedTranslations, table.Translations.GroupByLocale()["en"], table.MessageInfos)
+	if len(issues) != 0 {
+		t.Errorf("Expected no issue, got %v", issues)
+	}
+	for key, localeValueMap := range table.Translations {
+		for locale, value := range localeValueMap {
+			if data[key][locale] != value {
+				t.Errorf("Expected %s in %s to be read back as %q, got %q", key, locale, value, data[key][locale])
+			}
+		}
+	}
+}
+
+func TestParseReadablePlaceholders_Ambiguous(t *testing.T) {
+	messageInfos := KeyMessageInfoMap{
+		"key1": {PlaceholderTexts: map[string]string{"START_TAG_SPAN": "<span>", "START_TAG_SPAN_1": "<span>", "CLOSE_TAG_SPAN": "</span>"}},
+	}
+	data := KeyLocaleValueMap{"key1": {"fr": "<span>a</span>"}}
+
+	data, issues := ParseReadablePlaceholders(data, KeyValueMap{"key1": "${{START_TAG_SPAN}}a${{CLOSE_TAG_SPAN}}"}, messageInfos)
+	if data["key1"]["fr"] != "<span>a${{CLOSE_TAG_SPAN}}" {
+		t.Errorf("Expected ambiguous text to be left as is, got %q", data["key1"]["fr"])
+	}
+	if rules := getIssueRules(issues); !slices.Equal(rules, []string{RuleAmbiguousPlaceholder}) {
+		t.Errorf("Expected ambiguous text to be reported, got %v", issues)
+	}
+}
@@ -0,0 +1,109 @@
+package common
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "ngx-xlf-xlsx"
+	toolURI      = "https://www.npmjs.com/package/ngx-xlf-xlsx"
+)
+
+// The subset of SARIF used to report issues; see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver struct {
+		Name           string      `json:"name"`
+		Version        string      `json:"version,omitempty"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	} `json:"driver"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region *sarifRegion `json:"region,omitempty"`
+	} `json:"physicalLocation"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifLevels The SARIF level of each severity.
+var sarifLevels = map[Severity]string{
+	SeverityError:   "error",
+	SeverityWarning: "warning",
+	SeverityInfo:    "note",
+}
+
+// WriteSarif Writes the issues as SARIF, for CI tools to show them as annotations on the files they are in.
+func (r Report) WriteSarif(path Path, toolVersion string) error {
+	run := sarifRun{Results: []sarifResult{}}
+	run.Tool.Driver.Name = toolName
+	run.Tool.Driver.Version = toolVersion
+	run.Tool.Driver.InformationURI = toolURI
+	run.Tool.Driver.Rules = []sarifRule{}
+
+	for _, issue := range r.Issues {
+		if !slices.ContainsFunc(run.Tool.Driver.Rules, func(rule sarifRule) bool { return rule.ID == issue.Rule }) {
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: issue.Rule, ShortDescription: sarifMessage{Text: ruleDescriptions[issue.Rule]}})
+		}
+
+		result := sarifResult{
+			RuleID:  issue.Rule,
+			Level:   sarifLevels[issue.Severity],
+			Message: sarifMessage{Text: issue.getSummary()},
+		}
+		if issue.File != "" {
+			var location sarifLocation
+			location.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(string(issue.File))
+			if issue.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: issue.Line}
+			}
+			result.Locations = append(result.Locations, location)
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	bytes, err := json.MarshalIndent(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(string(path), append(bytes, '\n'), reportFilePermissions)
+}
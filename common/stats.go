@@ -0,0 +1,130 @@
+package common
+
+import (
+	"slices"
+)
+
+const (
+	// fuzzyMatchThreshold How similar a source string must be to the one of a translated message to be fuzzy-matchable, from 0 to 1.
+	fuzzyMatchThreshold = 0.75
+)
+
+// StatsCount A number of messages, and of words of their source strings.
+type StatsCount struct {
+	Messages int `json:"messages"`
+	Words    int `json:"words"`
+}
+
+func (c *StatsCount) add(value Value) {
+	c.Messages++
+	c.Words += CountWords(value)
+}
+
+// LocaleStats The work left in a non-source locale, as translation vendors quote it.
+// Each message to translate is counted in the first category it belongs to, in the order of the fields.
+type LocaleStats struct {
+	Locale Locale `json:"locale"`
+	// Repeated Messages with the same source string as a translated message, or as another message to translate.
+	Repeated StatsCount `json:"repeated"`
+	// FuzzyMatchable Messages with a source string similar to the one of a translated message.
+	FuzzyMatchable StatsCount `json:"fuzzyMatchable"`
+	// New Messages that were not in the translations file yet.
+	New StatsCount `json:"new"`
+	// Untranslated Messages whose translation is missing, stale, or invalid.
+	Untranslated StatsCount `json:"untranslated"`
+	Total        StatsCount `json:"total"`
+}
+
+// GetStats The work left in each non-source locale.
+// The new keys are the ones that were not in the translations file yet.
+func (tm *TranslationManager) GetStats(newKeys []Key) []LocaleStats {
+	var stats []LocaleStats
+
+	sourceKeys := slices.Sorted(slices.Values(tm.sourceKeys))
+	for _, locale := range tm.GetNonSourceLocales() {
+		localeStats := LocaleStats{Locale: locale}
+
+		var translatedSources, seenSources []Value
+		var keysToTranslate []Key
+		for _, key := range sourceKeys {
+			if tm.needsTranslation(key, locale) {
+				keysToTranslate = append(keysToTranslate, key)
+			} else {
+				translatedSources = append(translatedSources, tm.translations[key][tm.sourceLocale])
+			}
+		}
+
+		for _, key := range keysToTranslate {
+			source := tm.translations[key][tm.sourceLocale]
+
+			switch {
+			case slices.Contains(translatedSources, source) || slices.Contains(seenSources, source):
+				localeStats.Repeated.add(source)
+			case slices.ContainsFunc(translatedSources, func(translatedSource Value) bool { return isFuzzyMatch(source, translatedSource) }):
+				localeStats.FuzzyMatchable.add(source)
+			case slices.Contains(newKeys, key):
+				localeStats.New.add(source)
+			default:
+				localeStats.Untranslated.add(source)
+			}
+			localeStats.Total.add(source)
+			seenSources = append(seenSources, source)
+		}
+
+		stats = append(stats, localeStats)
+	}
+
+	return stats
+}
+
+// Whether the translation of a key in a locale is missing, stale, or invalid.
+// Translations inherited from a fallback locale are not.
+func (tm *TranslationManager) needsTranslation(key Key, locale Locale) bool {
+	translation := tm.translations[key][locale]
+	if _, fallbackLocale := tm.fallbacks.Resolve(tm.translations, key, locale); translation == defaultTranslationValue && fallbackLocale != "" {
+		return false
+	}
+
+	return translation == defaultTranslationValue ||
+		tm.IsStale(key, locale) ||
+		HasPlaceholderMismatch(tm.translations[key][tm.sourceLocale], translation) ||
+		ExceedsMaxLength(translation, tm.maxLengths[key])
+}
+
+// Whether two different source strings are similar enough for the translation of one to be a good start for the other.
+func isFuzzyMatch(source Value, otherSource Value) bool {
+	runes, otherRunes := []rune(string(source)), []rune(string(otherSource))
+	longest := max(len(runes), len(otherRunes))
+	if longest == 0 {
+		return false
+	}
+	// Strings too different in length cannot be similar enough, and are not worth comparing.
+	if float64(min(len(runes), len(otherRunes))) < fuzzyMatchThreshold*float64(longest) {
+		return false
+	}
+
+	return 1-float64(getEditDistance(runes, otherRunes))/float64(longest) >= fuzzyMatchThreshold
+}
+
+// The Levenshtein distance between two strings.
+func getEditDistance(runes []rune, otherRunes []rune) int {
+	previousRow := make([]int, len(otherRunes)+1)
+	row := make([]int, len(otherRunes)+1)
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+
+	for i := range runes {
+		row[0] = i + 1
+		for j := range otherRunes {
+			substitutionCost := 1
+			if runes[i] == otherRunes[j] {
+				substitutionCost = 0
+			}
+			row[j+1] = min(previousRow[j+1]+1, row[j]+1, previousRow[j]+substitutionCost)
+		}
+		previousRow, row = row, previousRow
+	}
+
+	return previousRow[len(otherRunes)]
+}
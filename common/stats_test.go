@@ -0,0 +1,35 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTranslationManager_GetStats(t *testing.T) {
+	translationManager := TranslationManager{}
+	translationManager.SetSourceLocale("en")
+	_ = translationManager.AddTranslations(KeyValueMap{
+		"key1": "Save the file",
+		"key2": "Save the files",
+		"key3": "Save the file",
+		"key4": "Delete everything now",
+		"key5": "Open settings",
+	}, "en")
+	_ = translationManager.AddTranslations(KeyValueMap{
+		"key1": "Enregistrer le fichier",
+	}, "fr")
+
+	stats := translationManager.GetStats([]Key{"key4"})
+
+	expectedStats := []LocaleStats{{
+		Locale:         "fr",
+		Repeated:       StatsCount{Messages: 1, Words: 3},
+		FuzzyMatchable: StatsCount{Messages: 1, Words: 3},
+		New:            StatsCount{Messages: 1, Words: 3},
+		Untranslated:   StatsCount{Messages: 1, Words: 2},
+		Total:          StatsCount{Messages: 4, Words: 11},
+	}}
+	if !reflect.DeepEqual(stats, expectedStats) {
+		t.Errorf("Expected %+v, got %+v", expectedStats, stats)
+	}
+}
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"regexp"
+	"slices"
+)
+
+const (
+	// PlaceholderInTextRegex A placeholder in its string representation; e.g. "${{INTERPOLATION}}".
+	PlaceholderInTextRegex = `\$\{\{([\s\S]*?)\}\}`
+	// PlaceholderSprintf Formats a placeholder ID into its string representation.
+	PlaceholderSprintf = "${{%s}}"
+)
+
+// TranslationStatus What, if anything, needs to be done about a translation.
+type TranslationStatus int
+
+const (
+	StatusTranslated TranslationStatus = iota
+	// StatusMissing The translation is empty.
+	StatusMissing
+	// StatusInvalid The placeholders of the translation do not match the ones of the source string, or the translation is too long.
+	StatusInvalid
+	// StatusStale The source string changed since the translation was made.
+	StatusStale
+	// StatusInherited The translation is empty, but the one of a fallback locale is used instead.
+	StatusInherited
+)
+
+// GetPlaceholderIDs Extract the ID of the placeholders from a string that might contain some.
+func GetPlaceholderIDs(value Value) []string {
+	regex := regexp.MustCompile(PlaceholderInTextRegex)
+	matches := regex.FindAllStringSubmatch(string(value), -1)
+
+	var results []string
+	for _, placeholderFoundArr := range matches {
+		results = append(results, placeholderFoundArr[1])
+	}
+
+	return results
+}
+
+// HasPlaceholderMismatch Whether the translation does not have the same placeholders as its source string.
+func HasPlaceholderMismatch(source Value, translation Value) bool {
+	sourcePlaceholderIDs := GetPlaceholderIDs(source)
+	translationPlaceholderIDs := GetPlaceholderIDs(translation)
+
+	if len(sourcePlaceholderIDs) != len(translationPlaceholderIDs) {
+		return true
+	}
+	for _, placeholderID := range sourcePlaceholderIDs {
+		if !slices.Contains(translationPlaceholderIDs, placeholderID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetStatus The status of the translation of a key in a non-source locale.
+func (t TranslationTable) GetStatus(key Key, locale Locale) TranslationStatus {
+	translation := t.Translations[key][locale]
+
+	switch {
+	case translation == defaultTranslationValue:
+		if _, fallbackLocale := t.Fallbacks.Resolve(t.Translations, key, locale); fallbackLocale != "" {
+			return StatusInherited
+		}
+		return StatusMissing
+	case HasPlaceholderMismatch(t.Translations[key][t.SourceLocale], translation), ExceedsMaxLength(translation, t.MessageInfos[key].MaxLength):
+		return StatusInvalid
+	case slices.Contains(t.StaleTranslations[key], locale):
+		return StatusStale
+	default:
+		return StatusTranslated
+	}
+}
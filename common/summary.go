@@ -0,0 +1,68 @@
+package common
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LocaleSummary How far along the translations of a non-source locale are.
+type LocaleSummary struct {
+	Locale     Locale
+	Messages   int
+	Translated int
+	// Inherited Messages without a translation of their own, using the one of a fallback locale; they are complete.
+	Inherited int
+	Missing   int
+	Stale     int
+	Invalid   int
+	// RemainingWords The number of words of the source strings of the messages that are not translated yet.
+	RemainingWords int
+}
+
+// GetPercentComplete The share of the messages that are translated, from 0 to 1.
+func (s LocaleSummary) GetPercentComplete() float64 {
+	if s.Messages == 0 {
+		return 1
+	}
+
+	return float64(s.Translated+s.Inherited) / float64(s.Messages)
+}
+
+// GetSummaries The summary of each non-source locale, in order.
+func (t TranslationTable) GetSummaries() []LocaleSummary {
+	var summaries []LocaleSummary
+
+	for _, locale := range t.NonSourceLocales {
+		summary := LocaleSummary{Locale: locale}
+		for key := range t.Translations {
+			summary.Messages++
+
+			status := t.GetStatus(key, locale)
+			switch status {
+			case StatusTranslated:
+				summary.Translated++
+			case StatusInherited:
+				summary.Inherited++
+			case StatusMissing:
+				summary.Missing++
+			case StatusStale:
+				summary.Stale++
+			case StatusInvalid:
+				summary.Invalid++
+			}
+			if status != StatusTranslated && status != StatusInherited {
+				summary.RemainingWords += CountWords(t.Translations[key][t.SourceLocale])
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries
+}
+
+// CountWords The number of words of a value, placeholders excluded, as translation vendors count them.
+func CountWords(value Value) int {
+	regex := regexp.MustCompile(PlaceholderInTextRegex)
+
+	return len(strings.Fields(regex.ReplaceAllString(string(value), " ")))
+}
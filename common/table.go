@@ -0,0 +1,192 @@
+package common
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// TableProblem Something wrong in a translations table, that was ignored while reading it.
+type TableProblem struct {
+	Sheet   string // Empty when the table has a single sheet; the name of the file for PO files.
+	Row     int    // 1-based; 0 when the problem is not about a row.
+	Column  string // The header of the column; empty when the problem is not about a column.
+	Message string
+}
+
+func (p TableProblem) String() string {
+	var location []string
+	if p.Sheet != "" {
+		location = append(location, "sheet "+strconv.Quote(p.Sheet))
+	}
+	if p.Row != 0 {
+		location = append(location, "row "+strconv.Itoa(p.Row))
+	}
+	if p.Column != "" {
+		location = append(location, "column "+strconv.Quote(p.Column))
+	}
+	if len(location) == 0 {
+		return p.Message
+	}
+
+	return strings.Join(location, ", ") + ": " + p.Message
+}
+
+// Converts the rows of a translations table, header included, to translations.
+// Columns are matched by their header: the keys are in the "key" column, and the values in the columns of the given locales.
+// Other columns are ignored, and reported as problems, except for the notes and max length columns.
+// Blank rows are skipped, while rows without a key, and rows of a key already read, are ignored and reported as problems.
+func getDataFromRows(rows [][]string, locales []Locale) (KeyLocaleValueMap, []TableProblem, error) {
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("missing header")
+	}
+
+	var problems []TableProblem
+	keyColumn := -1
+	localeColumns := map[int]Locale{}
+	hasColumn := map[Locale]bool{}
+	for j, header := range rows[0] {
+		locale, isLocale := matchLocaleHeader(header, locales)
+		switch {
+		case isSameHeader(header, keyColumnLabel) && keyColumn == -1:
+			keyColumn = j
+		case isSameHeader(header, notesColumnLabel) || isSameHeader(header, maxLengthColumnLabel) || strings.TrimSpace(header) == "":
+			continue
+		case isLocale && hasColumn[locale]:
+			problems = append(problems, TableProblem{Column: header, Message: "duplicate column of locale " + strconv.Quote(string(locale)) + ", ignored"})
+		case isLocale:
+			if isWrittenDifferently(header, locale) {
+				problems = append(problems, TableProblem{Column: header, Message: "locale " + strconv.Quote(string(locale)) + " is written differently in angular.json, column read anyway; rename it"})
+			}
+			localeColumns[j] = locale
+			hasColumn[locale] = true
+		case isLocaleHeader(header):
+			problems = append(problems, TableProblem{Column: header, Message: "locale is not one of the project, column ignored"})
+		default:
+			problems = append(problems, TableProblem{Column: header, Message: "unknown column, ignored"})
+		}
+	}
+	if keyColumn == -1 {
+		return nil, nil, fmt.Errorf("missing %s column", strconv.Quote(keyColumnLabel))
+	}
+
+	keyLocaleValueMap := KeyLocaleValueMap{}
+	keyRows := map[Key]int{}
+	for i, row := range rows[1:] {
+		rowNumber := i + 2
+		key := Key(getCell(row, keyColumn))
+
+		switch {
+		case isBlankRow(row):
+			continue
+		case strings.TrimSpace(string(key)) == "":
+			problems = append(problems, TableProblem{Row: rowNumber, Message: "values without a key, row ignored"})
+			continue
+		case keyRows[key] != 0:
+			problems = append(problems, TableProblem{Row: rowNumber, Message: fmt.Sprintf("duplicate key %s, already on row %d, row ignored", strconv.Quote(string(key)), keyRows[key])})
+			continue
+		}
+		keyRows[key] = rowNumber
+
+		keyLocaleValueMap[key] = LocaleValueMap{}
+		for j, locale := range localeColumns {
+			keyLocaleValueMap[key][locale] = Value(getCell(row, j))
+		}
+	}
+
+	return keyLocaleValueMap, problems, nil
+}
+
+// Whether a row has no value at all; e.g. a row left between two groups of keys.
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// The value of a cell, or an empty one, as trailing empty cells are not part of rows.
+func getCell(row []string, column int) string {
+	if column < len(row) {
+		return row[column]
+	}
+
+	return defaultTranslationValue
+}
+
+// Whether two headers are the same, ignoring surrounding whitespaces and case, as well as how locales are written; e.g. "fr_FR " and "fr-FR".
+func isSameHeader(header string, label string) bool {
+	header, label = strings.TrimSpace(header), strings.TrimSpace(label)
+	if strings.EqualFold(header, label) {
+		return true
+	}
+	if !isLocaleHeader(header) || !isLocaleHeader(label) {
+		return false
+	}
+
+	return language.Make(header) == language.Make(label)
+}
+
+// The locale a header is the one of, among the given ones.
+func matchLocaleHeader(header string, locales []Locale) (Locale, bool) {
+	for _, locale := range locales {
+		if isSameHeader(header, string(locale)) {
+			return locale, true
+		}
+	}
+
+	return "", false
+}
+
+// Whether a header matches a locale only once both are canonicalised; e.g. "fr_FR" or "fr-FX" for "fr-FR".
+// Case is not a difference, as BCP 47 language tags are case-insensitive.
+func isWrittenDifferently(header string, locale Locale) bool {
+	return !strings.EqualFold(strings.TrimSpace(header), string(locale))
+}
+
+// Whether the header of a column is the one of a locale, as opposed to a column added by translators; e.g. "Reviewer".
+func isLocaleHeader(header string) bool {
+	header = strings.TrimSpace(header)
+	if strings.EqualFold(header, keyColumnLabel) || strings.EqualFold(header, notesColumnLabel) {
+		return false
+	}
+	_, err := language.Parse(header)
+
+	return err == nil
+}
+
+// Converts translations to the rows of a translations table, header included, sorted by key.
+func getRowsFromData(translations KeyLocaleValueMap, sourceLocale Locale, nonSourceLocales []Locale) [][]string {
+	var locales []Locale
+	locales = append(locales, sourceLocale)
+	locales = append(locales, nonSourceLocales...)
+
+	header := []string{keyColumnLabel}
+	for _, locale := range locales {
+		header = append(header, string(locale))
+	}
+	rows := [][]string{header}
+
+	translationKeys := slices.Collect(maps.Keys(translations))
+	sort.Slice(translationKeys, func(i, j int) bool {
+		return translationKeys[i] < translationKeys[j]
+	})
+
+	for _, key := range translationKeys {
+		row := []string{string(key)}
+		for _, locale := range locales {
+			row = append(row, string(translations[key][locale]))
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
@@ -0,0 +1,99 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetDataFromRows_MatchesColumnsByHeader(t *testing.T) {
+	rows := [][]string{
+		{"FR ", "Notes", "Key", "en", "it"},
+		{"valeur1", "note", "key1", "value1", "valore1"},
+		{"", "", "key2"},
+	}
+
+	data, problems, err := getDataFromRows(rows, []Locale{"en", "fr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedData := KeyLocaleValueMap{
+		"key1": {"en": "value1", "fr": "valeur1"},
+		"key2": {"en": "", "fr": ""},
+	}
+	if !reflect.DeepEqual(data, expectedData) {
+		t.Errorf("Expected %v, got %v", expectedData, data)
+	}
+
+	expectedProblems := []TableProblem{
+		{Column: "it", Message: "locale is not one of the project, column ignored"},
+	}
+	if !reflect.DeepEqual(problems, expectedProblems) {
+		t.Errorf("Expected %v, got %v", expectedProblems, problems)
+	}
+}
+
+func TestGetDataFromRows_UnknownColumn(t *testing.T) {
+	rows := [][]string{
+		{"key", "en", "Reviewer", "fr_FR"},
+		{"key1", "value1", "Alice", "valeur1"},
+	}
+
+	data, problems, err := getDataFromRows(rows, []Locale{"en", "fr-FR"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data["key1"]["fr-FR"] != "valeur1" {
+		t.Errorf("Expected locale header to be normalised, got %v", data["key1"])
+	}
+	if len(problems) != 2 || problems[0].Column != "Reviewer" {
+		t.Errorf("Expected unknown column to be reported, got %v", problems)
+	}
+	if len(problems) == 2 && problems[1].Column != "fr_FR" {
+		t.Errorf("Expected locale written differently to be reported, got %v", problems[1])
+	}
+}
+
+func TestGetDataFromRows_MissingKeyColumn(t *testing.T) {
+	rows := [][]string{
+		{"id", "en", "fr"},
+		{"key1", "value1", "valeur1"},
+	}
+
+	_, _, err := getDataFromRows(rows, []Locale{"en", "fr"})
+	if err == nil {
+		t.Error("Expected missing key column to be an error")
+	}
+}
+
+func TestGetDataFromRows_MalformedRows(t *testing.T) {
+	rows := [][]string{
+		{"key", "en", "fr"},
+		{"key1", "value1", "valeur1"},
+		{},
+		{"", " ", ""},
+		{"", "value2", "valeur2"},
+		{"key1", "value1", "autre valeur1"},
+	}
+
+	data, problems, err := getDataFromRows(rows, []Locale{"en", "fr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedData := KeyLocaleValueMap{
+		"key1": {"en": "value1", "fr": "valeur1"},
+	}
+	if !reflect.DeepEqual(data, expectedData) {
+		t.Errorf("Expected %v, got %v", expectedData, data)
+	}
+
+	expectedProblems := []TableProblem{
+		{Row: 5, Message: "values without a key, row ignored"},
+		{Row: 6, Message: `duplicate key "key1", already on row 2, row ignored`},
+	}
+	if !reflect.DeepEqual(problems, expectedProblems) {
+		t.Errorf("Expected %v, got %v", expectedProblems, problems)
+	}
+}
@@ -2,23 +2,26 @@ package common
 
 import (
 	"fmt"
-	"regexp"
+	"maps"
 	"slices"
 	"sort"
 	"strconv"
-	"strings"
 )
 
 const (
 	defaultTranslationValue = ""
-	multipleSpacesRegex     = `\s{2,}`
 )
 
 type TranslationManager struct {
-	locales      []Locale
-	sourceLocale Locale
-	translations KeyLocaleValueMap
-	sourceKeys   []Key
+	locales           []Locale
+	sourceLocale      Locale
+	translations      KeyLocaleValueMap
+	sourceKeys        []Key
+	staleTranslations KeyLocalesMap
+	maxLengths        map[Key]int
+	normalizer        Normalizer
+	fallbacks         LocaleFallbacks
+	normalizations    []Normalization
 }
 
 func (tm *TranslationManager) SetSourceLocale(locale Locale) {
@@ -55,15 +58,32 @@ func (tm *TranslationManager) AddTranslations(valueMap KeyValueMap, locale Local
 
 	tm.EnsureLocale(locale)
 
-	multipleSpaces := regexp.MustCompile(multipleSpacesRegex)
-	for key, value := range valueMap {
+	// Sorted, so normalizations are in the order of the keys.
+	for _, key := range slices.Sorted(maps.Keys(valueMap)) {
+		value := valueMap[key]
 		if locale == tm.sourceLocale {
 			tm.ensureSourceKey(key)
 		}
 		tm.ensureTranslationsForKey(key)
-		value = Value(strings.Trim(string(value), " "))
-		value = Value(multipleSpaces.ReplaceAllString(string(value), " "))
-		tm.translations[key][locale] = value
+
+		var normalizedValue Value
+		var steps []string
+		if locale == tm.sourceLocale {
+			normalizedValue, steps = tm.normalizer.NormalizeSource(key, value)
+		} else {
+			normalizedValue, steps = tm.normalizer.Normalize(key, value, locale)
+		}
+		if len(steps) > 0 {
+			tm.normalizations = append(tm.normalizations, Normalization{
+				Key:      key,
+				Locale:   locale,
+				IsSource: locale == tm.sourceLocale,
+				Before:   value,
+				After:    normalizedValue,
+				Steps:    steps,
+			})
+		}
+		tm.translations[key][locale] = normalizedValue
 	}
 
 	return nil
@@ -122,3 +142,86 @@ func (tm *TranslationManager) GetExportableTranslations() KeyLocaleValueMap {
 func (tm *TranslationManager) GetTranslationsByLocale() LocaleKeyValueMap {
 	return tm.translations.GroupByLocale()
 }
+
+// SetFallbacks Sets the locale each locale falls back to, for the messages it has no translation of its own for.
+// The locales must all have been added before.
+func (tm *TranslationManager) SetFallbacks(fallbacks LocaleFallbacks) error {
+	err := fallbacks.Validate(tm.sourceLocale, tm.GetNonSourceLocales())
+	if err != nil {
+		return err
+	}
+	tm.fallbacks = fallbacks
+
+	return nil
+}
+
+// GetResolvedTranslationsByLocale The translations as they are to be used by the app, grouped by locale.
+// Locales with a fallback get the translation of the first locale of their chain with one, or else the source string.
+func (tm *TranslationManager) GetResolvedTranslationsByLocale() LocaleKeyValueMap {
+	translationsByLocale := tm.GetTranslationsByLocale()
+
+	for _, locale := range tm.GetNonSourceLocales() {
+		if len(tm.fallbacks.GetChain(locale)) == 0 {
+			continue
+		}
+		for key := range translationsByLocale[locale] {
+			translation, fallbackLocale := tm.fallbacks.Resolve(tm.translations, key, locale)
+			if fallbackLocale == "" {
+				translation = tm.translations[key][tm.sourceLocale]
+			}
+			translationsByLocale[locale][key] = translation
+		}
+	}
+
+	return translationsByLocale
+}
+
+// MarkStale Marks the translation of a key as made for a source string that changed since.
+func (tm *TranslationManager) MarkStale(key Key, locale Locale) {
+	if tm.staleTranslations == nil {
+		tm.staleTranslations = KeyLocalesMap{}
+	}
+	if tm.IsStale(key, locale) {
+		return
+	}
+	tm.staleTranslations[key] = append(tm.staleTranslations[key], locale)
+}
+
+func (tm *TranslationManager) IsStale(key Key, locale Locale) bool {
+	return slices.Contains(tm.staleTranslations[key], locale)
+}
+
+func (tm *TranslationManager) GetStaleTranslations() KeyLocalesMap {
+	return tm.staleTranslations
+}
+
+// GetStaleKeys The keys whose translation in the given locale is stale.
+func (tm *TranslationManager) GetStaleKeys(locale Locale) []Key {
+	var keys []Key
+
+	for key, locales := range tm.staleTranslations {
+		if slices.Contains(locales, locale) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// SetNormalizer Sets how source strings and translations are cleaned up when added; by default, with all the steps.
+func (tm *TranslationManager) SetNormalizer(normalizer Normalizer) {
+	tm.normalizer = normalizer
+}
+
+// GetNormalizations The source strings and translations changed by the normalizer, as they were added.
+func (tm *TranslationManager) GetNormalizations() []Normalization {
+	return tm.normalizations
+}
+
+// SetMaxLength Sets the maximum number of characters of the translations of a key, placeholders excluded.
+func (tm *TranslationManager) SetMaxLength(key Key, maxLength int) {
+	if tm.maxLengths == nil {
+		tm.maxLengths = map[Key]int{}
+	}
+	tm.maxLengths[key] = maxLength
+}
@@ -0,0 +1,63 @@
+package common
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// TranslationStore Where translators work on the translations, between two runs of the tool.
+type TranslationStore interface {
+	// EnsureExists Creates an empty store if there is none yet.
+	EnsureExists(sourceLocale Locale, nonSourceLocales []Locale) error
+	// GetData Loads the translations of the given locales currently in the store.
+	// What had to be ignored to do so is returned as problems, to be reported.
+	GetData(locales []Locale) (KeyLocaleValueMap, []TableProblem, error)
+	// Write Saves the translations to the store, replacing the ones it had.
+	Write(table TranslationTable) error
+}
+
+// ReadablePlaceholdersStore A store recording whether it was written with readable placeholders,
+// so its translations are read back the way they were written, whatever the options of the run.
+type ReadablePlaceholdersStore interface {
+	// HasReadablePlaceholders Whether the store was last written with readable placeholders; false if there is no store yet.
+	HasReadablePlaceholders() (bool, error)
+}
+
+// TranslationTable Everything a TranslationStore may need to save the translations.
+type TranslationTable struct {
+	Translations      KeyLocaleValueMap
+	MessageInfos      KeyMessageInfoMap
+	SourceLocale      Locale
+	NonSourceLocales  []Locale
+	StaleTranslations KeyLocalesMap
+	// ReadablePlaceholders Whether spreadsheets show placeholders as what they stand for, when they can be told apart; e.g. "<b>" for START_BOLD_TEXT.
+	// Only stores recording it support it; see ReadablePlaceholdersStore.
+	ReadablePlaceholders bool
+	// Fallbacks The locale each locale falls back to; only the translations that differ from the fallback are in the table.
+	Fallbacks LocaleFallbacks
+}
+
+// TranslationStoreOptions Options for the stores supporting them; the others ignore them.
+type TranslationStoreOptions struct {
+	SheetPerLocale bool
+}
+
+// NewTranslationStore Returns the store for the given file, based on its extension.
+func NewTranslationStore(path Path, options TranslationStoreOptions) (TranslationStore, error) {
+	extension := strings.ToLower(filepath.Ext(string(path)))
+
+	switch extension {
+	case XlsxExtension:
+		return &Xlsx{Path: path, SheetPerLocale: options.SheetPerLocale}, nil
+	case OdsExtension:
+		return &Ods{Path: path}, nil
+	case CsvExtension:
+		return &Csv{Path: path, Comma: ','}, nil
+	case TsvExtension:
+		return &Csv{Path: path, Comma: '\t'}, nil
+	default:
+		return nil, fmt.Errorf("unsupported translations file extension %s", strconv.Quote(extension))
+	}
+}
@@ -0,0 +1,31 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewTranslationStore(t *testing.T) {
+	for path, expected := range map[Path]TranslationStore{
+		"translations.xlsx": &Xlsx{Path: "translations.xlsx"},
+		"translations.ODS":  &Ods{Path: "translations.ODS"},
+		"translations.csv":  &Csv{Path: "translations.csv", Comma: ','},
+		"translations.tsv":  &Csv{Path: "translations.tsv", Comma: '\t'},
+	} {
+		store, err := NewTranslationStore(path, TranslationStoreOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(store, expected) {
+			t.Errorf("Expected store %#v for path %s, got %#v", expected, path, store)
+		}
+	}
+}
+
+func TestNewTranslationStore_Unsupported(t *testing.T) {
+	_, err := NewTranslationStore("translations.txt", TranslationStoreOptions{})
+	if err == nil {
+		t.Error("Expected an error for an unsupported extension")
+	}
+}
@@ -3,6 +3,7 @@ package common
 import (
 	"maps"
 	"slices"
+	"strings"
 )
 
 // Locale A locale, with or without country; e.g. "en-US", "fr", "nl-BE", etc.
@@ -27,6 +28,34 @@ type KeyLocaleValueMap map[Key]LocaleValueMap
 
 type LocaleKeyValueMap map[Locale]KeyValueMap
 
+type KeyLocalesMap map[Key][]Locale
+
+// MessageInfo Context about a translation key, meant to help translators; e.g. the description and meaning given by developers.
+type MessageInfo struct {
+	Description string
+	Meaning     string
+	Locations   []string // Places where the message is used; e.g. "src/app/app.component.html:12".
+	MaxLength   int      // The maximum number of characters of the translations, placeholders excluded; 0 when there is none.
+	// PlaceholderTexts What the placeholders of the message stand for in the template, by ID; e.g. "{{ user.name }}" for INTERPOLATION.
+	PlaceholderTexts map[string]string
+}
+
+type KeyMessageInfoMap map[Key]MessageInfo
+
+// GetNotes The description and meaning of the message, as a single text for translators.
+func (m MessageInfo) GetNotes() string {
+	var notes []string
+
+	if m.Description != "" {
+		notes = append(notes, m.Description)
+	}
+	if m.Meaning != "" {
+		notes = append(notes, "Meaning: "+m.Meaning)
+	}
+
+	return strings.Join(notes, "\n")
+}
+
 func (l LocalePathMap) GetLocales() []Locale {
 	return slices.Collect(maps.Keys(l))
 }
@@ -0,0 +1,370 @@
+package common
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// htmlTagRegex An HTML-like tag typed in a translation; e.g. "<b>", "</b>", or "<br/>".
+	htmlTagRegex = `<(/?)([a-zA-Z][\w-]*)[^<>]*?(/?)>`
+
+	// Angular names the placeholders of the elements of a message after them; e.g. START_BOLD_TEXT and CLOSE_BOLD_TEXT for <b>.
+	startElementPlaceholderPrefix = "START_"
+	closeElementPlaceholderPrefix = "CLOSE_"
+)
+
+// Punctuation marks of other scripts, with the one they stand for; e.g. the full stop of Chinese and Japanese.
+var punctuationEquivalents = map[rune]rune{
+	'。': '.',
+	'．': '.',
+	'！': '!',
+	'？': '?',
+	'؟': '?',
+	'：': ':',
+	'；': ';',
+	'，': ',',
+	'、': ',',
+	'…': '.',
+}
+
+// Tags that have no closing tag in HTML.
+var voidHtmlTags = []string{"br", "hr", "img", "input", "wbr"}
+
+// Translation A translation to validate, with what rules may need to know about it.
+type Translation struct {
+	Key         Key
+	Locale      Locale
+	Source      Value
+	Target      Value
+	MessageInfo MessageInfo
+}
+
+// ValidationRule A check of translations against their source string.
+// Check returns a message for each problem found, if any.
+type ValidationRule struct {
+	ID       string
+	Severity Severity
+	Check    func(translation Translation) []string
+	// ChecksMissing Whether missing translations are checked too, as they are written empty to the xlf files;
+	// the ones of locales with a fallback are not.
+	ChecksMissing bool
+}
+
+// RuleConfig How a rule is applied to a project; the zero value applies it as is.
+type RuleConfig struct {
+	Disabled    bool     `json:"disabled"`
+	Severity    Severity `json:"severity"`    // Empty to keep the default severity of the rule.
+	IgnoredKeys []Key    `json:"ignoredKeys"` // Keys the rule is not applied to; e.g. brand names for identical-to-source.
+}
+
+// DefaultValidationRules The rules validators have, unless others are added.
+var DefaultValidationRules = []ValidationRule{
+	{ID: RulePlaceholderCount, Severity: SeverityWarning, Check: checkPlaceholderCount, ChecksMissing: true},
+	{ID: RuleMissingPlaceholder, Severity: SeverityWarning, Check: checkMissingPlaceholders, ChecksMissing: true},
+	{ID: RuleUnknownPlaceholder, Severity: SeverityWarning, Check: checkUnknownPlaceholders},
+	{ID: RuleElementPlaceholders, Severity: SeverityWarning, Check: checkElementPlaceholders},
+	{ID: RuleMaxLength, Severity: SeverityError, Check: checkMaxLength},
+	{ID: RuleWhitespace, Severity: SeverityWarning, Check: checkWhitespace},
+	{ID: RuleTrailingPunctuation, Severity: SeverityWarning, Check: checkTrailingPunctuation},
+	{ID: RuleUnbalancedTags, Severity: SeverityWarning, Check: checkUnbalancedTags},
+	{ID: RuleDoubledWords, Severity: SeverityWarning, Check: checkDoubledWords},
+	{ID: RuleIdenticalToSource, Severity: SeverityInfo, Check: checkIdenticalToSource},
+}
+
+// Validator Runs rules over all the translations.
+type Validator struct {
+	Rules  []ValidationRule
+	Config map[string]RuleConfig // By rule ID.
+}
+
+// NewValidator Returns a validator with the default rules, configured for the project.
+func NewValidator(config map[string]RuleConfig) (Validator, error) {
+	validator := Validator{Rules: slices.Clone(DefaultValidationRules), Config: config}
+
+	for id, ruleConfig := range config {
+		if !slices.ContainsFunc(validator.Rules, func(rule ValidationRule) bool { return rule.ID == id }) {
+			return Validator{}, fmt.Errorf("unknown validation rule %s", strconv.Quote(id))
+		}
+		if ruleConfig.Severity != "" && !slices.Contains([]Severity{SeverityError, SeverityWarning, SeverityInfo}, ruleConfig.Severity) {
+			return Validator{}, fmt.Errorf("unknown severity %s for validation rule %s", strconv.Quote(string(ruleConfig.Severity)), strconv.Quote(id))
+		}
+	}
+
+	return validator, nil
+}
+
+// Validate Runs the enabled rules over the translations of all the non-source locales.
+// Missing translations are only checked by the rules meant for them, and inherited ones are not checked.
+func (v Validator) Validate(table TranslationTable) []Issue {
+	var issues []Issue
+
+	for _, key := range slices.Sorted(maps.Keys(table.Translations)) {
+		for _, locale := range table.NonSourceLocales {
+			translation := Translation{
+				Key:         key,
+				Locale:      locale,
+				Source:      table.Translations[key][table.SourceLocale],
+				Target:      table.Translations[key][locale],
+				MessageInfo: table.MessageInfos[key],
+			}
+			isMissing := translation.Target == defaultTranslationValue
+			// Written with the translation of a fallback locale, or else the source string.
+			if isMissing && len(table.Fallbacks.GetChain(locale)) > 0 {
+				continue
+			}
+
+			for _, rule := range v.Rules {
+				ruleConfig := v.Config[rule.ID]
+				if ruleConfig.Disabled || slices.Contains(ruleConfig.IgnoredKeys, key) || (isMissing && !rule.ChecksMissing) {
+					continue
+				}
+				severity := rule.Severity
+				if ruleConfig.Severity != "" {
+					severity = ruleConfig.Severity
+				}
+
+				for _, message := range rule.Check(translation) {
+					issues = append(issues, Issue{
+						Severity: severity,
+						Rule:     rule.ID,
+						Key:      key,
+						Locale:   locale,
+						Source:   translation.Source,
+						Target:   translation.Target,
+						Message:  message,
+					})
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+func checkPlaceholderCount(translation Translation) []string {
+	sourcePlaceholderIDs := GetPlaceholderIDs(translation.Source)
+	targetPlaceholderIDs := GetPlaceholderIDs(translation.Target)
+	if len(sourcePlaceholderIDs) == len(targetPlaceholderIDs) {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("placeholder count in translation does not match placeholder count in source string; source had %d %v but translation has %d %v",
+		len(sourcePlaceholderIDs), sourcePlaceholderIDs, len(targetPlaceholderIDs), targetPlaceholderIDs)}
+}
+
+func checkMissingPlaceholders(translation Translation) []string {
+	var messages []string
+
+	targetPlaceholderIDs := GetPlaceholderIDs(translation.Target)
+	for _, placeholderID := range slices.Compact(slices.Sorted(slices.Values(GetPlaceholderIDs(translation.Source)))) {
+		if !slices.Contains(targetPlaceholderIDs, placeholderID) {
+			messages = append(messages, fmt.Sprintf("placeholder %s present in source string is missing from translation", strconv.Quote(placeholderID)))
+		}
+	}
+
+	return messages
+}
+
+func checkUnknownPlaceholders(translation Translation) []string {
+	var messages []string
+
+	sourcePlaceholderIDs := GetPlaceholderIDs(translation.Source)
+	for _, placeholderID := range slices.Compact(slices.Sorted(slices.Values(GetPlaceholderIDs(translation.Target)))) {
+		if !slices.Contains(sourcePlaceholderIDs, placeholderID) {
+			messages = append(messages, fmt.Sprintf("placeholder %s is not in source string", strconv.Quote(placeholderID)))
+		}
+	}
+
+	return messages
+}
+
+func checkElementPlaceholders(translation Translation) []string {
+	// Messages whose source string is not balanced either are not Angular templates.
+	if len(getElementPlaceholderMessages(translation.Source)) > 0 {
+		return nil
+	}
+
+	return getElementPlaceholderMessages(translation.Target)
+}
+
+// A message for each closing element placeholder of a value that does not close the last opened element, and for each element left open.
+func getElementPlaceholderMessages(value Value) []string {
+	var messages []string
+	var openPlaceholderIDs []string
+
+	for _, placeholderID := range GetPlaceholderIDs(value) {
+		switch {
+		case strings.HasPrefix(placeholderID, startElementPlaceholderPrefix):
+			openPlaceholderIDs = append(openPlaceholderIDs, placeholderID)
+		case strings.HasPrefix(placeholderID, closeElementPlaceholderPrefix):
+			if len(openPlaceholderIDs) == 0 {
+				messages = append(messages, fmt.Sprintf("placeholder %s closes an element that is not opened", strconv.Quote(placeholderID)))
+				continue
+			}
+			lastOpenPlaceholderID := openPlaceholderIDs[len(openPlaceholderIDs)-1]
+			if getElementName(lastOpenPlaceholderID) != getElementName(placeholderID) {
+				messages = append(messages, fmt.Sprintf("placeholder %s does not close the last opened element, %s",
+					strconv.Quote(placeholderID), strconv.Quote(lastOpenPlaceholderID)))
+				continue
+			}
+			openPlaceholderIDs = openPlaceholderIDs[:len(openPlaceholderIDs)-1]
+		}
+	}
+	for _, placeholderID := range openPlaceholderIDs {
+		messages = append(messages, fmt.Sprintf("element opened by placeholder %s is never closed", strconv.Quote(placeholderID)))
+	}
+
+	return messages
+}
+
+// The name of the element of a placeholder, without the suffix Angular adds to tell apart the opening placeholders of elements of the same kind;
+// e.g. "TAG_SPAN" for START_TAG_SPAN_1, as all of them are closed by CLOSE_TAG_SPAN.
+func getElementName(placeholderID string) string {
+	name := strings.TrimPrefix(strings.TrimPrefix(placeholderID, startElementPlaceholderPrefix), closeElementPlaceholderPrefix)
+
+	return regexp.MustCompile(`_\d+$`).ReplaceAllString(name, "")
+}
+
+func checkMaxLength(translation Translation) []string {
+	if !ExceedsMaxLength(translation.Target, translation.MessageInfo.MaxLength) {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("translation is %d characters long, exceeding the maximum of %d",
+		GetLength(translation.Target), translation.MessageInfo.MaxLength)}
+}
+
+func checkWhitespace(translation Translation) []string {
+	var messages []string
+
+	source, target := string(translation.Source), string(translation.Target)
+	sourceLeading := source[:len(source)-len(strings.TrimLeftFunc(source, unicode.IsSpace))]
+	targetLeading := target[:len(target)-len(strings.TrimLeftFunc(target, unicode.IsSpace))]
+	if sourceLeading != targetLeading {
+		messages = append(messages, fmt.Sprintf("leading whitespace %s does not match the one of the source string, %s",
+			strconv.Quote(targetLeading), strconv.Quote(sourceLeading)))
+	}
+
+	sourceTrailing := source[len(strings.TrimRightFunc(source, unicode.IsSpace)):]
+	targetTrailing := target[len(strings.TrimRightFunc(target, unicode.IsSpace)):]
+	if sourceTrailing != targetTrailing {
+		messages = append(messages, fmt.Sprintf("trailing whitespace %s does not match the one of the source string, %s",
+			strconv.Quote(targetTrailing), strconv.Quote(sourceTrailing)))
+	}
+
+	return messages
+}
+
+func checkTrailingPunctuation(translation Translation) []string {
+	sourcePunctuation := getTrailingPunctuation(translation.Source)
+	targetPunctuation := getTrailingPunctuation(translation.Target)
+	if sourcePunctuation == targetPunctuation {
+		return nil
+	}
+
+	describe := func(punctuation rune) string {
+		if punctuation == 0 {
+			return "none"
+		}
+		return strconv.QuoteRune(punctuation)
+	}
+
+	return []string{fmt.Sprintf("translation ends with punctuation %s, while source string ends with %s",
+		describe(targetPunctuation), describe(sourcePunctuation))}
+}
+
+// The punctuation mark a value ends with, ignoring trailing whitespaces, placeholders, and closing quotes; 0 if there is none.
+func getTrailingPunctuation(value Value) rune {
+	regex := regexp.MustCompile(`(?:\s|` + PlaceholderInTextRegex + `|["'»”’)\]])+$`)
+	runes := []rune(regex.ReplaceAllString(string(value), ""))
+	if len(runes) == 0 {
+		return 0
+	}
+
+	last := runes[len(runes)-1]
+	if equivalent, ok := punctuationEquivalents[last]; ok {
+		return equivalent
+	}
+	if !slices.Contains([]rune{'.', '!', '?', ':', ';', ','}, last) {
+		return 0
+	}
+
+	return last
+}
+
+func checkUnbalancedTags(translation Translation) []string {
+	var messages []string
+	var openTags []string
+
+	regex := regexp.MustCompile(htmlTagRegex)
+	for _, match := range regex.FindAllStringSubmatch(string(translation.Target), -1) {
+		isClosing, name, isSelfClosing := match[1] == "/", strings.ToLower(match[2]), match[3] == "/"
+
+		switch {
+		case isSelfClosing || slices.Contains(voidHtmlTags, name):
+			continue
+		case !isClosing:
+			openTags = append(openTags, name)
+		case len(openTags) > 0 && openTags[len(openTags)-1] == name:
+			openTags = openTags[:len(openTags)-1]
+		default:
+			messages = append(messages, fmt.Sprintf("closing tag %s does not match any opening tag", strconv.Quote(match[0])))
+		}
+	}
+	for _, name := range openTags {
+		messages = append(messages, fmt.Sprintf("tag %s is never closed", strconv.Quote("<"+name+">")))
+	}
+
+	return messages
+}
+
+func checkDoubledWords(translation Translation) []string {
+	var messages []string
+
+	sourceDoubledWords := getDoubledWords(translation.Source)
+	for _, word := range getDoubledWords(translation.Target) {
+		// Doubled on purpose; e.g. "very very".
+		if slices.Contains(sourceDoubledWords, word) {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("word %s is doubled", strconv.Quote(word)))
+	}
+
+	return messages
+}
+
+// The words of a value that are directly followed by themselves, case insensitively.
+func getDoubledWords(value Value) []string {
+	var doubledWords []string
+
+	// A placeholder stands for other words between the ones around it; e.g. "de ${{COUNT}} de".
+	regex := regexp.MustCompile(PlaceholderInTextRegex)
+	for _, text := range regex.Split(string(value), -1) {
+		words := strings.Fields(text)
+		for i := 1; i < len(words); i++ {
+			word := strings.TrimFunc(words[i], unicode.IsPunct)
+			previousWord := strings.TrimFunc(words[i-1], unicode.IsPunct)
+			// Punctuation between the words means they belong to different phrases; e.g. "Yes, yes".
+			if word == "" || !strings.EqualFold(word, previousWord) || previousWord != strings.TrimLeftFunc(words[i-1], unicode.IsPunct) {
+				continue
+			}
+			doubledWords = append(doubledWords, strings.ToLower(word))
+		}
+	}
+
+	return doubledWords
+}
+
+func checkIdenticalToSource(translation Translation) []string {
+	if translation.Target != translation.Source || !strings.ContainsFunc(string(translation.Source), unicode.IsLetter) {
+		return nil
+	}
+
+	return []string{"translation is identical to the source string, and may not be translated"}
+}
@@ -0,0 +1,131 @@
+package common
+
+import (
+	"slices"
+	"testing"
+)
+
+func getValidationTable(source Value, target Value) TranslationTable {
+	return TranslationTable{
+		Translations:     KeyLocaleValueMap{"key1": {"en": source, "fr": target}},
+		MessageInfos:     KeyMessageInfoMap{},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"fr"},
+	}
+}
+
+func getIssueRules(issues []Issue) []string {
+	var rules []string
+	for _, issue := range issues {
+		rules = append(rules, issue.Rule)
+	}
+
+	return rules
+}
+
+func TestValidator_Validate(t *testing.T) {
+	validator, err := NewValidator(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		source        Value
+		target        Value
+		expectedRules []string
+	}{
+		{"Hello ${{NAME}}!", "Bonjour ${{NAME}} !", nil},
+		{"Hello ${{NAME}}!", "Bonjour !", []string{RulePlaceholderCount, RuleMissingPlaceholder}},
+		{"Hello!", "Bonjour ${{NAME}}!", []string{RulePlaceholderCount, RuleUnknownPlaceholder}},
+		{"${{NAME}} has ${{COUNT}} files, ${{NAME}}", "${{COUNT}} fichiers", []string{RulePlaceholderCount, RuleMissingPlaceholder}},
+		{"Files", "${{NAME}} a ${{COUNT}} fichiers, ${{NAME}}", []string{RulePlaceholderCount, RuleUnknownPlaceholder, RuleUnknownPlaceholder}},
+		{"${{START_BOLD_TEXT}}Hi${{CLOSE_BOLD_TEXT}} ${{START_TAG_SPAN}}a${{CLOSE_TAG_SPAN}}${{START_TAG_SPAN_1}}b${{CLOSE_TAG_SPAN}}",
+			"${{START_TAG_SPAN_1}}b${{CLOSE_TAG_SPAN}} ${{START_BOLD_TEXT}}Salut${{CLOSE_BOLD_TEXT}}${{START_TAG_SPAN}}a${{CLOSE_TAG_SPAN}}", nil},
+		{"${{START_BOLD_TEXT}}${{START_LINK}}Hi${{CLOSE_LINK}}${{CLOSE_BOLD_TEXT}}", "${{START_BOLD_TEXT}}${{START_LINK}}Salut${{CLOSE_BOLD_TEXT}}${{CLOSE_LINK}}",
+			[]string{RuleElementPlaceholders, RuleElementPlaceholders}},
+		{"${{START_BOLD_TEXT}}Hi${{CLOSE_BOLD_TEXT}}", "${{CLOSE_BOLD_TEXT}}Salut${{START_BOLD_TEXT}}", []string{RuleElementPlaceholders, RuleElementPlaceholders}},
+		{"Hello ", "Bonjour", []string{RuleWhitespace}},
+		{"Hello.", "Bonjour", []string{RuleTrailingPunctuation}},
+		{"你好。", "Bonjour.", nil},
+		{"<b>Hello</b>", "<b>Bonjour</i>", []string{RuleUnbalancedTags, RuleUnbalancedTags}},
+		{"Hello<br/>world", "Bonjour<br>monde", nil},
+		{"Send the file", "Envoyer le le fichier", []string{RuleDoubledWords}},
+		{"Yes, yes", "Oui, oui", nil},
+		{"Selection of ${{COUNT}} of your files", "Sélection de ${{COUNT}} de vos fichiers", nil},
+		{"Menu", "Menu", []string{RuleIdenticalToSource}},
+		{"Menu", "", nil},
+		{"Hello ${{NAME}}!", "", []string{RulePlaceholderCount, RuleMissingPlaceholder}},
+	} {
+		issues := validator.Validate(getValidationTable(testCase.source, testCase.target))
+		if rules := getIssueRules(issues); !slices.Equal(rules, testCase.expectedRules) {
+			t.Errorf("Expected %q for %q to break %v, got %v", testCase.target, testCase.source, testCase.expectedRules, rules)
+		}
+	}
+}
+
+func TestValidator_Validate_MaxLength(t *testing.T) {
+	validator, err := NewValidator(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := getValidationTable("Cancel", "Annuler")
+	table.MessageInfos["key1"] = MessageInfo{MaxLength: 7}
+	if issues := validator.Validate(table); len(issues) != 0 {
+		t.Errorf("Expected no issue, got %v", issues)
+	}
+
+	table.MessageInfos["key1"] = MessageInfo{MaxLength: 6}
+	if issues := validator.Validate(table); len(issues) != 1 || issues[0].Rule != RuleMaxLength || issues[0].Severity != SeverityError {
+		t.Errorf("Expected too long translation to be an error, got %v", issues)
+	}
+}
+
+func TestValidator_Validate_Config(t *testing.T) {
+	validator, err := NewValidator(map[string]RuleConfig{
+		RuleWhitespace:        {Disabled: true},
+		RuleDoubledWords:      {Severity: SeverityError},
+		RuleIdenticalToSource: {IgnoredKeys: []Key{"key1"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if issues := validator.Validate(getValidationTable("Menu ", "Menu")); len(issues) != 0 {
+		t.Errorf("Expected disabled and ignored rules not to be applied, got %v", issues)
+	}
+	if issues := validator.Validate(getValidationTable("Send the file", "Envoyer le le fichier")); len(issues) != 1 || issues[0].Severity != SeverityError {
+		t.Errorf("Expected severity to be overridden, got %v", issues)
+	}
+}
+
+func TestNewValidator(t *testing.T) {
+	if _, err := NewValidator(map[string]RuleConfig{"unknown": {}}); err == nil {
+		t.Error("Expected unknown rule to be refused")
+	}
+	if _, err := NewValidator(map[string]RuleConfig{RuleWhitespace: {Severity: "fatal"}}); err == nil {
+		t.Error("Expected unknown severity to be refused")
+	}
+}
+
+func TestValidator_Validate_Inherited(t *testing.T) {
+	validator, err := NewValidator(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := getValidationTable("Hello ${{NAME}}!", "Bonjour ${{NAME}} !")
+	table.Translations["key1"]["fr-BE"] = ""
+	table.NonSourceLocales = []Locale{"fr", "fr-BE"}
+	table.Fallbacks = LocaleFallbacks{"fr-BE": "fr"}
+	if issues := validator.Validate(table); len(issues) != 0 {
+		t.Errorf("Expected inherited translation not to be checked, got %v", issues)
+	}
+
+	// Missing in the whole chain, so written with the source string.
+	table.Translations["key1"]["fr"] = ""
+	table.NonSourceLocales = []Locale{"fr-BE"}
+	if issues := validator.Validate(table); len(issues) != 0 {
+		t.Errorf("Expected missing translation of a locale with a fallback not to be checked, got %v", issues)
+	}
+}
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// WhitespacePolicy How the whitespaces of the source string and translations of a message are cleaned up.
+type WhitespacePolicy string
+
+const (
+	// WhitespaceNormalize Leading and trailing spaces are removed, and runs of whitespaces become a single space; the default.
+	WhitespaceNormalize WhitespacePolicy = "normalize"
+	// WhitespaceTrim Leading and trailing spaces are removed, and the other whitespaces are kept.
+	WhitespaceTrim WhitespacePolicy = "trim"
+	// WhitespacePreserve Whitespaces are kept as is; e.g. for text around an element placeholder, or preformatted text.
+	WhitespacePreserve WhitespacePolicy = "preserve"
+
+	multipleSpacesRegex = `\s{2,}`
+
+	// NormalizationWhitespace The step of the normalization applying the whitespace policy, last.
+	NormalizationWhitespace = "whitespace"
+)
+
+var whitespacePolicies = []WhitespacePolicy{WhitespaceNormalize, WhitespaceTrim, WhitespacePreserve}
+
+// WhitespaceConfig The whitespace policy of a project, and of the keys that need another one.
+type WhitespaceConfig struct {
+	Policy WhitespacePolicy         `json:"policy"` // Empty for WhitespaceNormalize.
+	Keys   map[Key]WhitespacePolicy `json:"keys"`
+}
+
+func (c WhitespaceConfig) validate() error {
+	if c.Policy != "" && !slices.Contains(whitespacePolicies, c.Policy) {
+		return fmt.Errorf("unknown whitespace policy %s", strconv.Quote(string(c.Policy)))
+	}
+	for key, policy := range c.Keys {
+		if !slices.Contains(whitespacePolicies, policy) {
+			return fmt.Errorf("unknown whitespace policy %s for key %s", strconv.Quote(string(policy)), strconv.Quote(string(key)))
+		}
+	}
+
+	return nil
+}
+
+// The whitespace policy of a key.
+func (c WhitespaceConfig) getPolicy(key Key) WhitespacePolicy {
+	if policy, ok := c.Keys[key]; ok {
+		return policy
+	}
+	if c.Policy != "" {
+		return c.Policy
+	}
+
+	return WhitespaceNormalize
+}
+
+// Applies a whitespace policy to a source string or a translation.
+func applyWhitespacePolicy(value Value, policy WhitespacePolicy) Value {
+	switch policy {
+	case WhitespaceTrim:
+		return Value(strings.Trim(string(value), " "))
+	case WhitespacePreserve:
+		return value
+	default:
+		return NormalizeValue(value)
+	}
+}
+
+// NormalizeValue Cleans up the whitespaces of a value, as the default whitespace policy does.
+func NormalizeValue(value Value) Value {
+	multipleSpaces := regexp.MustCompile(multipleSpacesRegex)
+	value = Value(strings.Trim(string(value), " "))
+	value = Value(multipleSpaces.ReplaceAllString(string(value), " "))
+
+	return value
+}
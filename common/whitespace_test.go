@@ -0,0 +1,63 @@
+package common
+
+import "testing"
+
+func TestApplyWhitespacePolicy(t *testing.T) {
+	for policy, expectedValue := range map[WhitespacePolicy]Value{
+		WhitespaceNormalize: "Hello world",
+		WhitespaceTrim:      "Hello  world",
+		WhitespacePreserve:  " Hello  world ",
+	} {
+		if value := applyWhitespacePolicy(" Hello  world ", policy); value != expectedValue {
+			t.Errorf("Expected policy %s to give %q, got %q", policy, expectedValue, value)
+		}
+	}
+}
+
+func TestTranslationManager_AddTranslations_WhitespacePolicy(t *testing.T) {
+	normalizer, err := NewNormalizer(NormalizationConfig{Whitespace: WhitespaceConfig{
+		Policy: WhitespaceTrim,
+		Keys:   map[Key]WhitespacePolicy{"key2": WhitespacePreserve},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	translationManager := TranslationManager{}
+	translationManager.SetSourceLocale("en")
+	translationManager.SetNormalizer(normalizer)
+	_ = translationManager.AddTranslations(KeyValueMap{"key1": " Name:  ", "key2": "Hello "}, "en")
+	_ = translationManager.AddTranslations(KeyValueMap{"key1": "Nom :  ", "key2": "Bonjour "}, "fr")
+
+	translations := translationManager.GetExportableTranslations()
+	if translations["key1"]["en"] != "Name:" || translations["key2"]["en"] != "Hello " || translations["key2"]["fr"] != "Bonjour " {
+		t.Errorf("Expected the policy of each key to be applied to source and target, got %v", translations)
+	}
+
+	normalizations := translationManager.GetNormalizations()
+	if len(normalizations) != 2 || !normalizations[0].IsSource || normalizations[1].Locale != "fr" {
+		t.Errorf("Expected source and target changes to be reported, got %v", normalizations)
+	}
+}
+
+func TestNewNormalizer_WhitespacePolicy(t *testing.T) {
+	if _, err := NewNormalizer(NormalizationConfig{Whitespace: WhitespaceConfig{Keys: map[Key]WhitespacePolicy{"key1": "keep"}}}); err == nil {
+		t.Error("Expected unknown whitespace policy to be refused")
+	}
+}
+
+func TestGetNewNormalizations(t *testing.T) {
+	normalizations := []Normalization{
+		{Key: "key1", IsSource: true, Before: " Hello", After: "Hello"},
+		{Key: "key2", IsSource: true, Before: " Bye", After: "Bye"},
+		{Key: "key1", Locale: "fr", Before: " Bonjour", After: "Bonjour"},
+	}
+
+	if newNormalizations := GetNewNormalizations(normalizations, nil); len(newNormalizations) != 3 {
+		t.Errorf("Expected all normalizations to be new without a previous run, got %v", newNormalizations)
+	}
+	newNormalizations := GetNewNormalizations(normalizations, KeyValueMap{"key1": " Hello", "key2": "Bye"})
+	if len(newNormalizations) != 2 || newNormalizations[0].Key != "key2" || newNormalizations[1].Locale != "fr" {
+		t.Errorf("Expected normalization of unchanged source string not to be new, got %v", newNormalizations)
+	}
+}
@@ -1,69 +1,154 @@
 package common
 
 import (
+	"fmt"
 	"maps"
 	"os"
 	"slices"
-	"sort"
-	"unsafe"
+	"strconv"
+	"strings"
 
 	"github.com/xuri/excelize/v2"
 )
 
 const (
 	XlsxPath         = "./translations.xlsx"
+	XlsxExtension    = ".xlsx"
 	defaultSheetName = "Sheet1"
 	keyColumnLabel   = "key"
-	columnWidth      = 50
+	notesColumnLabel = "notes"
+	// maxLengthColumnLabel Only added when some messages have a maximum length.
+	maxLengthColumnLabel = "max length"
+	columnWidth          = 50
 )
 
 type Xlsx struct {
+	Path Path
+	// SheetPerLocale Whether each non-source locale has a sheet of its own, with the key, source, translation, and notes columns,
+	// instead of all the locales side by side in a single sheet.
+	SheetPerLocale bool
 }
 
-func (x *Xlsx) GetData() (KeyLocaleValueMap, error) {
-	keyLocaleValueMap := KeyLocaleValueMap{}
-
-	workbook, err := excelize.OpenFile(XlsxPath)
+func (x *Xlsx) GetData(locales []Locale) (KeyLocaleValueMap, []TableProblem, error) {
+	workbook, err := excelize.OpenFile(string(x.Path))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer workbook.Close()
 
+	// Reading a workbook in the other layout would read it as empty, and writing it would then drop its translations.
+	localeSheets, err := getLocaleSheets(workbook)
+	if err != nil {
+		return nil, nil, err
+	}
 	worksheetName := workbook.GetSheetName(0)
-
-	rows, err := workbook.GetRows(worksheetName)
+	rows, err := getSheetRows(workbook, worksheetName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	hasSingleSheet := len(localeSheets) == 0 && len(rows) > 0 && slices.ContainsFunc(rows[0], func(header string) bool { return isSameHeader(header, keyColumnLabel) })
+	if x.SheetPerLocale && hasSingleSheet {
+		return nil, nil, fmt.Errorf("%s has all the locales in a single sheet, not a sheet per locale; "+
+			"keep the layout it was written with, as its translations would be lost otherwise", x.Path)
+	}
+	if !x.SheetPerLocale && len(localeSheets) > 0 {
+		return nil, nil, fmt.Errorf("%s has a sheet per locale, not all the locales in a single sheet; "+
+			"keep the layout it was written with, as its translations would be lost otherwise", x.Path)
+	}
+	metadata, err := readMetadata(workbook)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = checkSummarySheet(workbook, metadata)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", x.Path, err)
 	}
 
-	var locales []string
-	for i, row := range rows {
-		if i == 0 {
-			row = row[1:]
-			locales = row
+	if x.SheetPerLocale {
+		return x.getSheetPerLocaleData(workbook, locales)
+	}
+
+	return getDataFromRows(rows, locales)
+}
+
+// The sheets written for a locale, when each locale has a sheet of its own, whether the locale is still one of the project or not.
+// These are named after their locale, which is the third column of their header, after the key and the source locale.
+func getLocaleSheets(workbook *excelize.File) ([]string, error) {
+	var worksheetNames []string
 
+	for _, worksheetName := range workbook.GetSheetList() {
+		if !isLocaleHeader(worksheetName) {
 			continue
 		}
+		rows, err := getSheetRows(workbook, worksheetName)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) > 0 && len(rows[0]) >= 3 && isSameHeader(rows[0][0], keyColumnLabel) && isSameHeader(rows[0][2], worksheetName) {
+			worksheetNames = append(worksheetNames, worksheetName)
+		}
+	}
+
+	return worksheetNames, nil
+}
 
-		key := row[0]
-		values := row[1:]
+// Every sheet holds the source and the translations of a single locale.
+// Sheets may have been removed, e.g. when the workbook was sent to a single translator.
+func (x *Xlsx) getSheetPerLocaleData(workbook *excelize.File, locales []Locale) (KeyLocaleValueMap, []TableProblem, error) {
+	keyLocaleValueMap := KeyLocaleValueMap{}
+	var problems []TableProblem
+
+	for _, worksheetName := range workbook.GetSheetList() {
+		// Other sheets may have been added by translators.
+		if _, ok := matchLocaleHeader(worksheetName, locales); !ok {
+			continue
+		}
 
-		keyLocaleValueMap[Key(key)] = LocaleValueMap{}
+		rows, err := getSheetRows(workbook, worksheetName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(rows) == 0 {
+			continue
+		}
 
-		for j, locale := range locales {
-			if j < len(values) {
-				keyLocaleValueMap[Key(key)][Locale(locale)] = Value(values[j])
-			} else {
-				keyLocaleValueMap[Key(key)][Locale(locale)] = defaultTranslationValue
+		sheetData, sheetProblems, err := getDataFromRows(rows, locales)
+		if err != nil {
+			return nil, nil, fmt.Errorf("sheet %s: %w", strconv.Quote(worksheetName), err)
+		}
+		for _, problem := range sheetProblems {
+			problem.Sheet = worksheetName
+			problems = append(problems, problem)
+		}
+
+		for key, localeValueMap := range sheetData {
+			if _, ok := keyLocaleValueMap[key]; !ok {
+				keyLocaleValueMap[key] = LocaleValueMap{}
+			}
+			for locale, value := range localeValueMap {
+				keyLocaleValueMap[key][locale] = value
 			}
 		}
 	}
 
-	return keyLocaleValueMap, nil
+	return keyLocaleValueMap, problems, nil
+}
+
+func (x *Xlsx) HasReadablePlaceholders() (bool, error) {
+	workbook, err := excelize.OpenFile(string(x.Path))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer workbook.Close()
+
+	return hasReadablePlaceholders(workbook)
 }
 
 func (x *Xlsx) EnsureExists(sourceLocale Locale, nonSourceLocales []Locale) error {
-	_, err := os.Stat(XlsxPath)
+	_, err := os.Stat(string(x.Path))
 	if err == nil {
 		return nil
 	}
@@ -71,65 +156,461 @@ func (x *Xlsx) EnsureExists(sourceLocale Locale, nonSourceLocales []Locale) erro
 		return err
 	}
 
-	workbook := excelize.NewFile()
-	defer workbook.Close()
-
-	return x.Write(KeyLocaleValueMap{}, sourceLocale, nonSourceLocales)
+	return x.Write(TranslationTable{
+		Translations:     KeyLocaleValueMap{},
+		SourceLocale:     sourceLocale,
+		NonSourceLocales: nonSourceLocales,
+	})
 }
 
-func (x *Xlsx) Write(translations KeyLocaleValueMap, sourceLocale Locale, nonSourceLocales []Locale) error {
-	workbook := excelize.NewFile()
+// Write Updates the existing workbook in place, if any, so what translators added to it is kept;
+// e.g. comments, styling, filters, other columns, and other sheets.
+// Only the rows of the keys, and the columns of the locales, are added or removed.
+func (x *Xlsx) Write(table TranslationTable) error {
+	workbook, isNew, err := x.openOrCreate()
+	if err != nil {
+		return err
+	}
 	defer workbook.Close()
 
-	sheetIndex, err := workbook.NewSheet(defaultSheetName)
+	metadata, err := readMetadata(workbook)
 	if err != nil {
 		return err
 	}
+	err = checkSummarySheet(workbook, metadata)
+	if err != nil {
+		return fmt.Errorf("%s: %w", x.Path, err)
+	}
+	metadata[metadataReadablePlaceholders] = strconv.FormatBool(table.ReadablePlaceholders)
 
-	worksheetName := workbook.GetSheetName(sheetIndex)
+	if !x.SheetPerLocale {
+		worksheetName := defaultSheetName
+		if !isNew {
+			worksheetName = workbook.GetSheetName(0)
+		}
 
-	var locales []Locale
-	locales = append(locales, sourceLocale)
-	locales = append(locales, nonSourceLocales...)
+		rows := addMaxLengthColumn(getRowsFromData(table.getDisplayedTranslations(), table.SourceLocale, table.NonSourceLocales), table)
+		columns, rowNumbers, err := updateSheet(workbook, worksheetName, rows, metadata)
+		if err != nil {
+			return err
+		}
+		err = styleSheet(workbook, worksheetName, rows, columns, rowNumbers, table)
+		if err != nil {
+			return err
+		}
+		err = addValidations(workbook, worksheetName, rows, columns, rowNumbers, table)
+		if err != nil {
+			return err
+		}
+		err = writeSummarySheet(workbook, table, metadata)
+		if err != nil {
+			return err
+		}
+
+		return x.save(workbook, metadata)
+	}
+
+	for _, locale := range table.NonSourceLocales {
+		rows := getRowsFromData(table.getDisplayedTranslations(), table.SourceLocale, []Locale{locale})
+		rows[0] = append(rows[0], notesColumnLabel)
+		for i, row := range rows[1:] {
+			rows[i+1] = append(row, table.MessageInfos[Key(row[0])].GetNotes())
+		}
+		rows = addMaxLengthColumn(rows, table)
 
-	// Write header.
-	headerLocales := locales
-	headerLocales = append([]Locale{keyColumnLabel}, headerLocales...)
-	for i, locale := range headerLocales {
-		cellAddress, err := excelize.CoordinatesToCellName(i+1, 0+1)
+		columns, rowNumbers, err := updateSheet(workbook, string(locale), rows, metadata)
+		if err != nil {
+			return err
+		}
+		err = styleSheet(workbook, string(locale), rows, columns, rowNumbers, table)
 		if err != nil {
 			return err
 		}
-		err = workbook.SetCellValue(worksheetName, cellAddress, string(locale))
+		err = addValidations(workbook, string(locale), rows, columns, rowNumbers, table)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Sheets of locales that are not in the project anymore; the ones added by translators are kept.
+	localeSheets, err := getLocaleSheets(workbook)
+	if err != nil {
+		return err
+	}
+	for _, worksheetName := range localeSheets {
+		if _, ok := matchLocaleHeader(worksheetName, table.NonSourceLocales); ok {
+			continue
+		}
+		err = workbook.DeleteSheet(worksheetName)
+		if err != nil {
+			return err
+		}
+	}
+
+	// A new workbook comes with a default sheet, which is of no use here.
+	if isNew && len(table.NonSourceLocales) > 0 {
+		err = workbook.DeleteSheet(defaultSheetName)
+		if err != nil {
+			return err
+		}
+	}
+	err = writeSummarySheet(workbook, table, metadata)
+	if err != nil {
+		return err
+	}
+
+	return x.save(workbook, metadata)
+}
+
+// Save the workbook, with what the tool recorded about it; what was recorded about sheets that are gone is dropped.
+func (x *Xlsx) save(workbook *excelize.File, metadata map[string]string) error {
+	maps.DeleteFunc(metadata, func(name string, _ string) bool {
+		return strings.HasPrefix(name, metadataColumnsPrefix) && !slices.Contains(workbook.GetSheetList(), strings.TrimPrefix(name, metadataColumnsPrefix))
+	})
+	err := writeMetadata(workbook, metadata)
+	if err != nil {
+		return err
+	}
+
+	return workbook.SaveAs(string(x.Path))
+}
+
+// Add the maximum length of each message as the last column, if any message has one.
+func addMaxLengthColumn(rows [][]string, table TranslationTable) [][]string {
+	if !slices.ContainsFunc(slices.Collect(maps.Values(table.MessageInfos)), func(messageInfo MessageInfo) bool { return messageInfo.MaxLength > 0 }) {
+		return rows
+	}
+
+	rows[0] = append(rows[0], maxLengthColumnLabel)
+	for i, row := range rows[1:] {
+		maxLength := ""
+		if table.MessageInfos[Key(row[0])].MaxLength > 0 {
+			maxLength = strconv.Itoa(table.MessageInfos[Key(row[0])].MaxLength)
+		}
+		rows[i+1] = append(row, maxLength)
+	}
+
+	return rows
+}
+
+func (x *Xlsx) openOrCreate() (*excelize.File, bool, error) {
+	_, err := os.Stat(string(x.Path))
+	if os.IsNotExist(err) {
+		return excelize.NewFile(), true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	workbook, err := excelize.OpenFile(string(x.Path))
+
+	return workbook, false, err
+}
+
+// sheetAdjustment An insertion or removal of a row or column, at the given 1-based index.
+type sheetAdjustment struct {
+	isColumn bool
+	index    int
+	offset   int // 1 for an insertion, -1 for a removal.
+}
+
+// Update a sheet so its managed columns and rows match the given ones, header included.
+// The first column of the given rows holds the keys, used to match rows.
+// Columns with an unknown header are left untouched, as well as the styling of the cells.
+// The headers written are recorded in the metadata, so only the columns written by the tool are ever removed.
+// Returns the index of the column of each of the given headers, and the number of the row of each of the given rows.
+func updateSheet(workbook *excelize.File, worksheetName string, rows [][]string, metadata map[string]string) ([]int, []int, error) {
+	var writtenHeader []string
+	if header, ok := metadata[metadataColumnsPrefix+worksheetName]; ok {
+		writtenHeader = strings.Split(header, metadataListSeparator)
+	}
+	metadata[metadataColumnsPrefix+worksheetName] = strings.Join(rows[0], metadataListSeparator)
+
+	index, err := workbook.GetSheetIndex(worksheetName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if index == -1 {
+		return writeSheet(workbook, worksheetName, rows)
+	}
+
+	existingRows, err := getSheetRows(workbook, worksheetName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(existingRows) == 0 {
+		return writeSheet(workbook, worksheetName, rows)
+	}
+
+	comments, err := workbook.GetComments(worksheetName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var adjustments []sheetAdjustment
+	columns, err := updateSheetColumns(workbook, worksheetName, existingRows[0], rows[0], writtenHeader, &adjustments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existingRows, err = getSheetRows(workbook, worksheetName)
+	if err != nil {
+		return nil, nil, err
+	}
+	rowNumbers, err := updateSheetRows(workbook, worksheetName, existingRows, columns[0], rows[1:], &adjustments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = moveComments(workbook, worksheetName, comments, adjustments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existingRows, err = getSheetRows(workbook, worksheetName)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, row := range rows {
+		for j, cell := range row {
+			// Unchanged cells are left as is, so their rich text is kept.
+			if rowNumbers[i]-1 < len(existingRows) && getCell(existingRows[rowNumbers[i]-1], columns[j]) == cell {
+				continue
+			}
+
+			cellAddress, err := excelize.CoordinatesToCellName(columns[j]+1, rowNumbers[i])
+			if err != nil {
+				return nil, nil, err
+			}
+			err = workbook.SetCellStr(worksheetName, cellAddress, cell)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return columns, rowNumbers, nil
+}
+
+// Read the rows of a sheet, with the exact text of string cells, regardless of their number format.
+// Other cells, such as numbers and dates, are read as they are displayed, which is the closest to what was typed.
+func getSheetRows(workbook *excelize.File, worksheetName string) ([][]string, error) {
+	rows, err := workbook.GetRows(worksheetName, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+	formattedRows, err := workbook.GetRows(worksheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows {
+		for j := range row {
+			cellAddress, err := excelize.CoordinatesToCellName(j+1, i+1)
+			if err != nil {
+				return nil, err
+			}
+			cellType, err := workbook.GetCellType(worksheetName, cellAddress)
+			if err != nil {
+				return nil, err
+			}
+			if cellType != excelize.CellTypeSharedString && cellType != excelize.CellTypeInlineString && cellType != excelize.CellTypeFormula && i < len(formattedRows) {
+				row[j] = getCell(formattedRows[i], j)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+// Remove the columns written by a previous run that are not wanted anymore, e.g. of removed locales, and add the missing ones.
+// Columns added by translators are kept, even when their header looks like a locale; e.g. "ID" or "No".
+// Returns the index of the column of each of the given headers.
+func updateSheetColumns(workbook *excelize.File, worksheetName string, existingHeader []string, header []string, writtenHeader []string, adjustments *[]sheetAdjustment) ([]int, error) {
+	for i := len(existingHeader) - 1; i >= 0; i-- {
+		isGenerated := slices.ContainsFunc(writtenHeader, func(label string) bool { return isSameHeader(existingHeader[i], label) })
+		if slices.ContainsFunc(header, func(label string) bool { return isSameHeader(existingHeader[i], label) }) || !isGenerated {
+			continue
+		}
+
+		columnName, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return nil, err
+		}
+		err = workbook.RemoveCol(worksheetName, columnName)
+		if err != nil {
+			return nil, err
+		}
+		*adjustments = append(*adjustments, sheetAdjustment{isColumn: true, index: i + 1, offset: -1})
+		existingHeader = slices.Delete(existingHeader, i, i+1)
+	}
+
+	columns := make([]int, len(header))
+	for i, label := range header {
+		index := slices.IndexFunc(existingHeader, func(existingLabel string) bool { return isSameHeader(existingLabel, label) })
+		if index != -1 {
+			columns[i] = index
+			continue
+		}
+
+		// Add the missing column right after the previous one, or first.
+		index = 0
+		if i > 0 {
+			index = columns[i-1] + 1
+		}
+		columnName, err := excelize.ColumnNumberToName(index + 1)
+		if err != nil {
+			return nil, err
+		}
+		if index < len(existingHeader) {
+			err = workbook.InsertCols(worksheetName, columnName, 1)
+			if err != nil {
+				return nil, err
+			}
+			*adjustments = append(*adjustments, sheetAdjustment{isColumn: true, index: index + 1, offset: 1})
+
+			// Columns already found after the inserted one have moved; e.g. the key column, when moved to the end by translators.
+			for j := range columns[:i] {
+				if columns[j] >= index {
+					columns[j]++
+				}
+			}
+		}
+		err = workbook.SetColWidth(worksheetName, columnName, columnName, columnWidth)
+		if err != nil {
+			return nil, err
+		}
+		existingHeader = slices.Insert(existingHeader, min(index, len(existingHeader)), label)
+		columns[i] = index
+	}
+
+	return columns, nil
+}
+
+// Remove the rows of the keys that are not wanted anymore, and add the missing ones, in order.
+// Rows without a key are left untouched.
+// Returns the number of the row of the header, followed by the one of each of the given rows.
+func updateSheetRows(workbook *excelize.File, worksheetName string, existingRows [][]string, keyColumn int, rows [][]string, adjustments *[]sheetAdjustment) ([]int, error) {
+	var keys []string
+	for _, row := range rows {
+		keys = append(keys, row[0])
+	}
+
+	// Existing keys, by row, header excluded.
+	var existingKeys []string
+	for _, row := range existingRows[1:] {
+		existingKey := ""
+		if keyColumn < len(row) {
+			existingKey = row[keyColumn]
+		}
+		existingKeys = append(existingKeys, existingKey)
+	}
+
+	for i := len(existingKeys) - 1; i >= 0; i-- {
+		if existingKeys[i] == "" || slices.Contains(keys, existingKeys[i]) {
+			continue
+		}
+
+		err := workbook.RemoveRow(worksheetName, i+2)
+		if err != nil {
+			return nil, err
+		}
+		*adjustments = append(*adjustments, sheetAdjustment{index: i + 2, offset: -1})
+		existingKeys = slices.Delete(existingKeys, i, i+1)
+	}
+
+	for _, key := range keys {
+		if slices.Contains(existingKeys, key) {
+			continue
+		}
+
+		// Add the missing row before the first one with a greater key, so sorted rows stay sorted.
+		index := slices.IndexFunc(existingKeys, func(existingKey string) bool { return existingKey > key })
+		if index == -1 {
+			index = len(existingKeys)
+		} else {
+			err := workbook.InsertRows(worksheetName, index+2, 1)
+			if err != nil {
+				return nil, err
+			}
+			*adjustments = append(*adjustments, sheetAdjustment{index: index + 2, offset: 1})
+		}
+		existingKeys = slices.Insert(existingKeys, index, key)
+	}
+
+	rowNumbers := []int{1}
+	for _, key := range keys {
+		rowNumbers = append(rowNumbers, slices.Index(existingKeys, key)+2)
+	}
+
+	return rowNumbers, nil
+}
+
+// Move the comments along with their cells, as excelize does not when inserting or removing rows and columns.
+// Comments of removed cells are removed as well.
+func moveComments(workbook *excelize.File, worksheetName string, comments []excelize.Comment, adjustments []sheetAdjustment) error {
+	if len(adjustments) == 0 {
+		return nil
+	}
+
+	for _, comment := range comments {
+		err := workbook.DeleteComment(worksheetName, comment.Cell)
 		if err != nil {
 			return err
 		}
 	}
 
-	// Write translations.
-	translationKeys := slices.Collect(maps.Keys(translations))
-	translationKeysStr := *((*[]string)(unsafe.Pointer(&translationKeys)))
-	sort.Strings(translationKeysStr)
-	translationKeys = *((*[]Key)(unsafe.Pointer(&translationKeysStr)))
+	for _, comment := range comments {
+		column, row, err := excelize.CellNameToCoordinates(comment.Cell)
+		if err != nil {
+			return err
+		}
 
-	for i, key := range translationKeys {
-		cellAddress, err := excelize.CoordinatesToCellName(0+1, i+1+1)
+		for _, adjustment := range adjustments {
+			coordinate := &row
+			if adjustment.isColumn {
+				coordinate = &column
+			}
+			if *coordinate == adjustment.index && adjustment.offset < 0 {
+				*coordinate = 0
+				break
+			}
+			if *coordinate >= adjustment.index {
+				*coordinate += adjustment.offset
+			}
+		}
+		if column == 0 || row == 0 {
+			continue
+		}
+
+		comment.Cell, err = excelize.CoordinatesToCellName(column, row)
 		if err != nil {
 			return err
 		}
-		err = workbook.SetCellValue(worksheetName, cellAddress, string(key))
+		err = workbook.AddComment(worksheetName, comment)
 		if err != nil {
 			return err
 		}
+	}
+
+	return nil
+}
+
+func writeSheet(workbook *excelize.File, worksheetName string, rows [][]string) ([]int, []int, error) {
+	_, err := workbook.NewSheet(worksheetName)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		for j, locale := range locales {
-			cellAddress, err := excelize.CoordinatesToCellName(j+1+1, i+1+1)
+	for i, row := range rows {
+		for j, cell := range row {
+			cellAddress, err := excelize.CoordinatesToCellName(j+1, i+1)
 			if err != nil {
-				return err
+				return nil, nil, err
 			}
-			err = workbook.SetCellValue(worksheetName, cellAddress, string(translations[key][locale]))
+			err = workbook.SetCellStr(worksheetName, cellAddress, cell)
 			if err != nil {
-				return err
+				return nil, nil, err
 			}
 		}
 	}
@@ -137,16 +618,24 @@ func (x *Xlsx) Write(translations KeyLocaleValueMap, sourceLocale Locale, nonSou
 	// Set column width.
 	startCol, err := excelize.ColumnNumberToName(1)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	endCol, err := excelize.ColumnNumberToName(len(headerLocales))
+	endCol, err := excelize.ColumnNumberToName(len(rows[0]))
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	err = workbook.SetColWidth(worksheetName, startCol, endCol, columnWidth)
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
+
+	var columns, rowNumbers []int
+	for j := range rows[0] {
+		columns = append(columns, j)
+	}
+	for i := range rows {
+		rowNumbers = append(rowNumbers, i+1)
 	}
 
-	return workbook.SaveAs(XlsxPath)
+	return columns, rowNumbers, nil
 }
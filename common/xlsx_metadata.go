@@ -0,0 +1,86 @@
+package common
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	// metadataSheetName The sheet where the tool records what it needs to know about the workbooks it writes, by name.
+	// It is very hidden, so translators cannot unhide it from Excel.
+	metadataSheetName = "ngx-xlf-xlsx"
+	// metadataListSeparator Separates the items of a recorded list; headers written by the tool have no line breaks.
+	metadataListSeparator = "\n"
+)
+
+// The names recorded in the metadata sheet, by prefix when followed by a key or a sheet name.
+const (
+	// metadataColumnsPrefix The headers of the columns written in a sheet, to tell them from the ones added by translators.
+	metadataColumnsPrefix = "columns:"
+	// metadataSplitTranslationPrefix The translation of a key when the per-locale workbook was written, to tell what the translator changed.
+	metadataSplitTranslationPrefix = "split-translation:"
+	// metadataReadablePlaceholders Whether the translations were written with readable placeholders, so they are read back the same way.
+	metadataReadablePlaceholders = "readable-placeholders"
+	// metadataSummarySheet The name of the sheet with the progress of the locales, to tell it from a sheet of translators with the same name.
+	metadataSummarySheet = "summary-sheet"
+)
+
+// Read what the tool recorded in a workbook, if anything; workbooks written by older versions have nothing recorded.
+func readMetadata(workbook *excelize.File) (map[string]string, error) {
+	metadata := map[string]string{}
+
+	index, err := workbook.GetSheetIndex(metadataSheetName)
+	if err != nil || index == -1 {
+		return metadata, err
+	}
+	rows, err := workbook.GetRows(metadataSheetName, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if len(row) == 0 || row[0] == "" {
+			continue
+		}
+		metadata[row[0]] = getCell(row, 1)
+	}
+
+	return metadata, nil
+}
+
+// Replace what the tool recorded in a workbook.
+func writeMetadata(workbook *excelize.File, metadata map[string]string) error {
+	err := workbook.DeleteSheet(metadataSheetName)
+	if err != nil {
+		return err
+	}
+	_, err = workbook.NewSheet(metadataSheetName)
+	if err != nil {
+		return err
+	}
+
+	for i, name := range slices.Sorted(maps.Keys(metadata)) {
+		cellAddress, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		err = workbook.SetSheetRow(metadataSheetName, cellAddress, &[]string{name, metadata[name]})
+		if err != nil {
+			return err
+		}
+	}
+
+	return workbook.SetSheetVisible(metadataSheetName, false, true)
+}
+
+// Whether a workbook was written with readable placeholders; workbooks written by older versions were not.
+func hasReadablePlaceholders(workbook *excelize.File) (bool, error) {
+	metadata, err := readMetadata(workbook)
+	if err != nil {
+		return false, err
+	}
+
+	return metadata[metadataReadablePlaceholders] == strconv.FormatBool(true), nil
+}
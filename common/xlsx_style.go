@@ -0,0 +1,219 @@
+package common
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	readOnlyFillColor  = "D9D9D9" // Grey.
+	missingFillColor   = "FFC7CE" // Red.
+	invalidFillColor   = "F8CBAD" // Orange.
+	staleFillColor     = "FFEB9C" // Yellow.
+	inheritedFillColor = "EDEDED" // Light grey.
+
+	// textNumberFormat The built-in "@" number format, so Excel keeps what is typed as is, instead of converting it to a number or a date.
+	textNumberFormat = 49
+)
+
+// The fill of the cells of translations, depending on their status.
+// Translated cells keep whatever fill they have.
+var statusFillColors = map[TranslationStatus]string{
+	StatusMissing:   missingFillColor,
+	StatusInvalid:   invalidFillColor,
+	StatusStale:     staleFillColor,
+	StatusInherited: inheritedFillColor,
+}
+
+// cellStyler Changes the fill, font, and protection of cells, while keeping the rest of their style.
+type cellStyler struct {
+	workbook      *excelize.File
+	worksheetName string
+	styles        map[cellStyle]int // New style of cells, by their previous style and the changes.
+}
+
+// cellStyle Changes made to the style of a cell.
+type cellStyle struct {
+	previousStyleID int
+	fillColor       string // Empty to remove the fill set by a previous run, if any.
+	locked          bool
+	bold            bool
+}
+
+// Highlight what translators need to work on, and grey out and lock what they should not edit.
+// All the cells are formatted as text.
+// The header is frozen, and filters are added to it.
+func styleSheet(workbook *excelize.File, worksheetName string, rows [][]string, columns []int, rowNumbers []int, table TranslationTable) error {
+	styler := cellStyler{workbook: workbook, worksheetName: worksheetName, styles: map[cellStyle]int{}}
+
+	for j, label := range rows[0] {
+		isReadOnly := j == 0 || label == string(table.SourceLocale) || label == notesColumnLabel || label == maxLengthColumnLabel
+
+		for i, row := range rows {
+			cellAddress, err := excelize.CoordinatesToCellName(columns[j]+1, rowNumbers[i])
+			if err != nil {
+				return err
+			}
+
+			style := cellStyle{locked: true}
+			switch {
+			case i == 0:
+				style.bold = true
+			case isReadOnly:
+				style.fillColor = readOnlyFillColor
+			default:
+				style.fillColor = statusFillColors[table.GetStatus(Key(row[0]), Locale(label))]
+				style.locked = false
+			}
+
+			err = styler.setStyle(cellAddress, style)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	sheetRows, err := workbook.GetRows(worksheetName)
+	if err != nil {
+		return err
+	}
+	columnCount := 0
+	for _, row := range sheetRows {
+		columnCount = max(columnCount, len(row))
+	}
+
+	// Columns added by translators are theirs to edit.
+	for column := range columnCount {
+		if slices.Contains(columns, column) {
+			continue
+		}
+		for rowNumber := 2; rowNumber <= len(sheetRows); rowNumber++ {
+			cellAddress, err := excelize.CoordinatesToCellName(column+1, rowNumber)
+			if err != nil {
+				return err
+			}
+			err = styler.setProtection(cellAddress, false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = workbook.SetPanes(worksheetName, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	})
+	if err != nil {
+		return err
+	}
+
+	// Filter on all the columns, including the ones added by translators.
+	bottomRightCell, err := excelize.CoordinatesToCellName(columnCount, max(len(sheetRows), 2))
+	if err != nil {
+		return err
+	}
+	err = workbook.AutoFilter(worksheetName, "A1:"+bottomRightCell, nil)
+	if err != nil {
+		return err
+	}
+
+	// Locked cells can only be edited once the protection is removed, which prevents accidental edits.
+	// There is no password, as this is not meant as a security measure.
+	return workbook.ProtectSheet(worksheetName, &excelize.SheetProtectionOptions{
+		AutoFilter:          true,
+		EditObjects:         true,
+		FormatCells:         true,
+		FormatColumns:       true,
+		FormatRows:          true,
+		InsertColumns:       true,
+		InsertHyperlinks:    true,
+		SelectLockedCells:   true,
+		SelectUnlockedCells: true,
+		Sort:                true,
+	})
+}
+
+func (s *cellStyler) setStyle(cellAddress string, style cellStyle) error {
+	previousStyleID, err := s.workbook.GetCellStyle(s.worksheetName, cellAddress)
+	if err != nil {
+		return err
+	}
+	style.previousStyleID = previousStyleID
+
+	styleID, ok := s.styles[style]
+	if !ok {
+		newStyle, err := s.workbook.GetStyle(previousStyleID)
+		if err != nil {
+			return err
+		}
+
+		if style.fillColor != "" {
+			newStyle.Fill = excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{style.fillColor}}
+		} else if isGeneratedFill(newStyle.Fill) {
+			newStyle.Fill = excelize.Fill{}
+		}
+		newStyle.Protection = &excelize.Protection{Locked: style.locked}
+		newStyle.NumFmt = textNumberFormat
+		newStyle.CustomNumFmt = nil
+		if style.bold {
+			if newStyle.Font == nil {
+				newStyle.Font = &excelize.Font{}
+			}
+			newStyle.Font.Bold = true
+		}
+
+		styleID, err = s.workbook.NewStyle(newStyle)
+		if err != nil {
+			return err
+		}
+		s.styles[style] = styleID
+	}
+
+	return s.workbook.SetCellStyle(s.worksheetName, cellAddress, cellAddress, styleID)
+}
+
+// Change only whether the cell is locked, keeping the rest of its style.
+func (s *cellStyler) setProtection(cellAddress string, locked bool) error {
+	previousStyleID, err := s.workbook.GetCellStyle(s.worksheetName, cellAddress)
+	if err != nil {
+		return err
+	}
+
+	newStyle, err := s.workbook.GetStyle(previousStyleID)
+	if err != nil {
+		return err
+	}
+	if newStyle.Protection != nil && newStyle.Protection.Locked == locked {
+		return nil
+	}
+	newStyle.Protection = &excelize.Protection{Locked: locked}
+
+	styleID, err := s.workbook.NewStyle(newStyle)
+	if err != nil {
+		return err
+	}
+
+	return s.workbook.SetCellStyle(s.worksheetName, cellAddress, cellAddress, styleID)
+}
+
+// Whether the fill was set by a previous run, as opposed to by translators.
+func isGeneratedFill(fill excelize.Fill) bool {
+	if fill.Type != "pattern" || len(fill.Color) != 1 {
+		return false
+	}
+
+	// Colors may be read back with an alpha channel; e.g. "FFFFC7CE".
+	color := strings.ToUpper(strings.TrimPrefix(fill.Color[0], "#"))
+	for _, generatedColor := range append(slices.Collect(maps.Values(statusFillColors)), readOnlyFillColor) {
+		if strings.HasSuffix(color, generatedColor) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,113 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	summarySheetName = "Summary"
+	// percentNumberFormat The built-in "0%" number format.
+	percentNumberFormat = 9
+)
+
+var summaryHeader = []string{"locale", "messages", "translated", "inherited", "missing", "stale", "invalid", "% complete", "remaining words"}
+
+// Refuse a workbook with a Summary sheet not written by the tool, as it would be replaced.
+// Older versions did not record it, so the Summary sheet of workbooks they wrote is recognized by its header.
+func checkSummarySheet(workbook *excelize.File, metadata map[string]string) error {
+	index, err := workbook.GetSheetIndex(summarySheetName)
+	if err != nil || index == -1 || metadata[metadataSummarySheet] == summarySheetName {
+		return err
+	}
+	if _, ok := metadata[metadataSummarySheet]; !ok {
+		rows, err := workbook.GetRows(summarySheetName)
+		if err != nil {
+			return err
+		}
+		if len(rows) > 0 && slices.Equal(rows[0], summaryHeader) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("sheet %s was not written by ngx-xlf-xlsx; rename it, as the progress of the locales is written to a sheet with that name",
+		strconv.Quote(summarySheetName))
+}
+
+// Write the Summary sheet, with the progress of each non-source locale.
+// It is generated from scratch every run, so it is always up to date.
+// A Summary sheet not written by the tool must have been refused by checkSummarySheet beforehand.
+func writeSummarySheet(workbook *excelize.File, table TranslationTable, metadata map[string]string) error {
+	err := workbook.DeleteSheet(summarySheetName)
+	if err != nil {
+		return err
+	}
+	metadata[metadataSummarySheet] = summarySheetName
+	_, err = workbook.NewSheet(summarySheetName)
+	if err != nil {
+		return err
+	}
+
+	for j, label := range summaryHeader {
+		cellAddress, err := excelize.CoordinatesToCellName(j+1, 1)
+		if err != nil {
+			return err
+		}
+		err = workbook.SetCellStr(summarySheetName, cellAddress, label)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, summary := range table.GetSummaries() {
+		row := []any{
+			string(summary.Locale),
+			summary.Messages,
+			summary.Translated,
+			summary.Inherited,
+			summary.Missing,
+			summary.Stale,
+			summary.Invalid,
+			summary.GetPercentComplete(),
+			summary.RemainingWords,
+		}
+		cellAddress, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
+		}
+		err = workbook.SetSheetRow(summarySheetName, cellAddress, &row)
+		if err != nil {
+			return err
+		}
+	}
+
+	percentStyleID, err := workbook.NewStyle(&excelize.Style{NumFmt: percentNumberFormat})
+	if err != nil {
+		return err
+	}
+	err = workbook.SetColStyle(summarySheetName, "H", percentStyleID)
+	if err != nil {
+		return err
+	}
+	headerStyleID, err := workbook.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	err = workbook.SetRowStyle(summarySheetName, 1, 1, headerStyleID)
+	if err != nil {
+		return err
+	}
+	err = workbook.SetColWidth(summarySheetName, "A", "I", float64(len("remaining words")+2))
+	if err != nil {
+		return err
+	}
+
+	// The summary is generated, so there is nothing to edit in it.
+	return workbook.ProtectSheet(summarySheetName, &excelize.SheetProtectionOptions{
+		SelectLockedCells:   true,
+		SelectUnlockedCells: true,
+	})
+}
@@ -0,0 +1,643 @@
+package common
+
+import (
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestXlsx_WriteGetData(t *testing.T) {
+	for _, sheetPerLocale := range []bool{false, true} {
+		xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx")), SheetPerLocale: sheetPerLocale}
+
+		err := xlsxFile.Write(TranslationTable{
+			Translations: KeyLocaleValueMap{
+				"key1": {"en": "value1", "fr": "valeur1", "de": "Wert1"},
+				"key2": {"en": "value2"},
+			},
+			MessageInfos: KeyMessageInfoMap{
+				"key1": {Description: "description"},
+			},
+			SourceLocale:     "en",
+			NonSourceLocales: []Locale{"de", "fr"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, _, err := xlsxFile.GetData([]Locale{"en", "de", "fr"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data["key1"]["en"] != "value1" || data["key1"]["fr"] != "valeur1" || data["key1"]["de"] != "Wert1" {
+			t.Errorf("Expected translations to be read back, got %v", data["key1"])
+		}
+		if value, ok := data["key2"]["fr"]; !ok || value != "" {
+			t.Error("Expected missing translation to be read back as empty")
+		}
+		if _, ok := data["key1"][notesColumnLabel]; ok {
+			t.Error("Expected notes not to be read as a locale")
+		}
+	}
+}
+
+func TestXlsx_Write_SheetPerLocale(t *testing.T) {
+	xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx")), SheetPerLocale: true}
+
+	err := xlsxFile.Write(TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": "value1", "fr": "valeur1", "de": "Wert1"},
+		},
+		MessageInfos: KeyMessageInfoMap{
+			"key1": {Description: "description", Meaning: "meaning"},
+		},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"de", "fr"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err := excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer workbook.Close()
+
+	sheets := workbook.GetSheetList()
+	if !reflect.DeepEqual(sheets, []string{"de", "fr", summarySheetName, metadataSheetName}) {
+		t.Fatalf("Expected one sheet per locale, the summary, and the metadata, got %v", sheets)
+	}
+
+	rows, err := workbook.GetRows("fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows[0][2] != "fr" || rows[1][2] != "valeur1" || rows[1][3] != "description\nMeaning: meaning" {
+		t.Errorf("Expected key, source, translation, and notes columns, got %v", rows)
+	}
+}
+
+func TestXlsx_GetData_OtherLayout(t *testing.T) {
+	for _, sheetPerLocale := range []bool{false, true} {
+		xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx")), SheetPerLocale: sheetPerLocale}
+		err := xlsxFile.Write(TranslationTable{
+			Translations:     KeyLocaleValueMap{"key1": {"en": "value1", "fr": "valeur1"}},
+			SourceLocale:     "en",
+			NonSourceLocales: []Locale{"fr"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		xlsxFile.SheetPerLocale = !sheetPerLocale
+		if _, _, err := xlsxFile.GetData([]Locale{"en", "fr"}); err == nil {
+			t.Errorf("Expected workbook written with a sheet per locale: %v to be refused in the other layout", sheetPerLocale)
+		}
+	}
+}
+
+func TestXlsx_Write_SheetPerLocale_RemovedLocale(t *testing.T) {
+	xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx")), SheetPerLocale: true}
+	table := TranslationTable{
+		Translations:     KeyLocaleValueMap{"key1": {"en": "value1", "fr": "valeur1", "nl": "waarde1"}},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"fr", "nl"},
+	}
+	err := xlsxFile.Write(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Translators add a sheet of their own, named after a locale.
+	workbook, err := excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = workbook.NewSheet("de")
+	_ = workbook.SetCellValue("de", "A1", "Glossar")
+	_ = workbook.Save()
+	_ = workbook.Close()
+
+	table.NonSourceLocales = []Locale{"fr"}
+	err = xlsxFile.Write(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err = excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer workbook.Close()
+	if sheets := workbook.GetSheetList(); !reflect.DeepEqual(sheets, []string{"fr", "de", summarySheetName, metadataSheetName}) {
+		t.Errorf("Expected the sheet of the removed locale to be deleted, and the other ones kept, got %v", sheets)
+	}
+}
+
+func TestXlsx_Write_InPlace(t *testing.T) {
+	xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx"))}
+
+	err := xlsxFile.Write(TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": "value1", "fr": "valeur1", "nl": "waarde1"},
+			"key3": {"en": "value3", "fr": "valeur3", "nl": "waarde3"},
+		},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"fr", "nl"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Translators add a column, a comment, some styling, and a sheet.
+	workbook, err := excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.SetCellValue(defaultSheetName, "E1", "Reviewer")
+	_ = workbook.SetCellValue(defaultSheetName, "E3", "Alice")
+	_ = workbook.AddComment(defaultSheetName, excelize.Comment{Cell: "C3", Author: "Alice", Text: "Check this"})
+	styleID, _ := workbook.NewStyle(&excelize.Style{Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}})
+	_ = workbook.SetCellStyle(defaultSheetName, "C3", "C3", styleID)
+	_, _ = workbook.NewSheet("Glossary")
+	err = workbook.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.Close()
+
+	// A key is added, a locale is removed and another one added.
+	err = xlsxFile.Write(TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": "value1", "fr": "valeur1", "de": "Wert1"},
+			"key2": {"en": "value2", "fr": "valeur2", "de": "Wert2"},
+			"key3": {"en": "value3", "fr": "valeur3", "de": "Wert3"},
+		},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"de", "fr"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err = excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer workbook.Close()
+
+	rows, err := workbook.GetRows(defaultSheetName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedRows := [][]string{
+		{"key", "en", "de", "fr", "Reviewer"},
+		{"key1", "value1", "Wert1", "valeur1"},
+		{"key2", "value2", "Wert2", "valeur2"},
+		{"key3", "value3", "Wert3", "valeur3", "Alice"},
+	}
+	if !reflect.DeepEqual(rows, expectedRows) {
+		t.Errorf("Expected rows %v, got %v", expectedRows, rows)
+	}
+
+	comments, err := workbook.GetComments(defaultSheetName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 || comments[0].Cell != "D4" || comments[0].Text != "Check this" {
+		t.Errorf("Expected comment to follow its cell, got %v", comments)
+	}
+	cellStyleID, _ := workbook.GetCellStyle(defaultSheetName, "D4")
+	if cellStyle, _ := workbook.GetStyle(cellStyleID); len(cellStyle.Fill.Color) != 1 || cellStyle.Fill.Color[0] != "FFFF00" {
+		t.Error("Expected styling to follow its cell")
+	}
+	if !slices.Contains(workbook.GetSheetList(), "Glossary") {
+		t.Error("Expected other sheets to be kept")
+	}
+}
+
+func TestXlsx_Write_InPlace_LocaleLikeColumns(t *testing.T) {
+	xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx"))}
+	table := TranslationTable{
+		Translations:     KeyLocaleValueMap{"key1": {"en": "value1", "fr": "valeur1", "nl": "waarde1"}},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"fr", "nl"},
+	}
+	err := xlsxFile.Write(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Translators add columns whose header could be read as a locale; e.g. Indonesian and Norwegian.
+	workbook, err := excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.SetSheetRow(defaultSheetName, "E1", &[]string{"ID", "No"})
+	_ = workbook.SetSheetRow(defaultSheetName, "E2", &[]string{"T-42", "3"})
+	_ = workbook.Save()
+	_ = workbook.Close()
+
+	table.NonSourceLocales = []Locale{"fr"}
+	err = xlsxFile.Write(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err = excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer workbook.Close()
+	rows, err := workbook.GetRows(defaultSheetName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedRows := [][]string{
+		{"key", "en", "fr", "ID", "No"},
+		{"key1", "value1", "valeur1", "T-42", "3"},
+	}
+	if !reflect.DeepEqual(rows, expectedRows) {
+		t.Errorf("Expected only the column of the removed locale to be removed, got %v", rows)
+	}
+}
+
+func TestXlsx_Write_InPlace_ReorderedColumns(t *testing.T) {
+	xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx"))}
+	table := TranslationTable{
+		Translations:     KeyLocaleValueMap{"key1": {"en": "value1", "fr": "valeur1", "de": "Wert1"}},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"fr"},
+	}
+	err := xlsxFile.Write(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Translators move the key column to the end.
+	workbook, err := excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.SetSheetRow(defaultSheetName, "A1", &[]string{"en", "fr", "key"})
+	_ = workbook.SetSheetRow(defaultSheetName, "A2", &[]string{"value1", "valeur1", "key1"})
+	_ = workbook.Save()
+	_ = workbook.Close()
+
+	table.NonSourceLocales = []Locale{"de", "fr"}
+	err = xlsxFile.Write(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err = excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer workbook.Close()
+	rows, err := workbook.GetRows(defaultSheetName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedRows := [][]string{
+		{"en", "de", "fr", "key"},
+		{"value1", "Wert1", "valeur1", "key1"},
+	}
+	if !reflect.DeepEqual(rows, expectedRows) {
+		t.Errorf("Expected the column of the new locale to be added, and the moved ones kept, got %v", rows)
+	}
+}
+
+func TestXlsx_Write_Status(t *testing.T) {
+	xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx"))}
+
+	err := xlsxFile.Write(TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": "value1", "fr": "valeur1"},
+			"key2": {"en": "value2", "fr": ""},
+			"key3": {"en": "${{NAME}}", "fr": "nom"},
+			"key4": {"en": "value4", "fr": "valeur4"},
+		},
+		SourceLocale:      "en",
+		NonSourceLocales:  []Locale{"fr"},
+		StaleTranslations: KeyLocalesMap{"key4": {"fr"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err := excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer workbook.Close()
+
+	for cellAddress, expectedColor := range map[string]string{
+		"A2": readOnlyFillColor,
+		"B2": readOnlyFillColor,
+		"C2": "",
+		"C3": missingFillColor,
+		"C4": invalidFillColor,
+		"C5": staleFillColor,
+	} {
+		styleID, _ := workbook.GetCellStyle(defaultSheetName, cellAddress)
+		style, err := workbook.GetStyle(styleID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		color := ""
+		if len(style.Fill.Color) > 0 {
+			color = style.Fill.Color[0]
+		}
+		if color != expectedColor {
+			t.Errorf("Expected cell %s to have color %s, got %s", cellAddress, expectedColor, color)
+		}
+		if locked := style.Protection == nil || style.Protection.Locked; locked != (cellAddress[0] != 'C') {
+			t.Errorf("Expected only translation cells to be unlocked, got %s locked: %t", cellAddress, locked)
+		}
+	}
+}
+
+func TestXlsx_GetData_ExactText(t *testing.T) {
+	xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx"))}
+
+	err := xlsxFile.Write(TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": "00123", "fr": ""},
+			"key2": {"en": "value2", "fr": ""},
+			"key3": {"en": "1.10", "fr": ""},
+		},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"fr"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err := excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	styleID, _ := workbook.GetCellStyle(defaultSheetName, "C2")
+	if style, _ := workbook.GetStyle(styleID); style.NumFmt != textNumberFormat {
+		t.Errorf("Expected cells to be formatted as text, got number format %d", style.NumFmt)
+	}
+
+	// Translators type a rich text, and a number in a cell that lost its text format.
+	_ = workbook.SetCellStr(defaultSheetName, "C2", "00123")
+	_ = workbook.SetCellRichText(defaultSheetName, "C3", []excelize.RichTextRun{
+		{Text: "valeur "},
+		{Text: "2", Font: &excelize.Font{Bold: true}},
+	})
+	numberStyleID, _ := workbook.NewStyle(&excelize.Style{NumFmt: 2}) // "0.00"
+	_ = workbook.SetCellFloat(defaultSheetName, "C4", 1.1, -1, 64)
+	_ = workbook.SetCellStyle(defaultSheetName, "C4", "C4", numberStyleID)
+	err = workbook.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.Close()
+
+	data, _, err := xlsxFile.GetData([]Locale{"en", "fr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, expectedValue := range map[Key]Value{"key1": "00123", "key2": "valeur 2", "key3": "1.10"} {
+		if data[key]["fr"] != expectedValue {
+			t.Errorf("Expected %s to be read as %q, got %q", key, expectedValue, data[key]["fr"])
+		}
+	}
+	if data["key1"]["en"] != "00123" {
+		t.Errorf("Expected source to be read as text, got %q", data["key1"]["en"])
+	}
+}
+
+func TestXlsx_Write_PlaceholderValidation(t *testing.T) {
+	xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx"))}
+	table := TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": "value1", "fr": "valeur1"},
+			"key2": {"en": "Hello ${{NAME}}, ${{COUNT}}", "fr": ""},
+			"key3": {"en": "${{COUNT}} messages for ${{NAME}}", "fr": ""},
+			"key4": {"en": "Bye ${{NAME}}", "fr": ""},
+			"key5": {"en": "${{NAME}}: ${{COUNT}}", "fr": ""},
+		},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"fr"},
+	}
+
+	err := xlsxFile.Write(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Translators' own conditional formats, looking like the ones of the tool, are kept.
+	workbook, err := excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.SetConditionalFormat(defaultSheetName, "B2:B6", []excelize.ConditionalFormatOptions{{Type: "formula", Criteria: "LEN(B2)>100"}})
+	_ = workbook.SetConditionalFormat(defaultSheetName, "D2:D6", []excelize.ConditionalFormatOptions{{Type: "formula", Criteria: `ISNUMBER(FIND("TODO",C2))`}})
+	err = workbook.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.Close()
+
+	// Written again, to check what the previous run added is replaced.
+	err = xlsxFile.Write(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err = excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer workbook.Close()
+
+	dataValidations, err := workbook.GetDataValidations(defaultSheetName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dataValidations) != 2 || dataValidations[0].Sqref != "C3:C4 C6" || dataValidations[1].Sqref != "C5" {
+		t.Fatalf("Expected cells sharing placeholders to share a data validation, got %v", dataValidations)
+	}
+	if prompt := *dataValidations[0].Prompt; prompt != "The translation must contain ${{COUNT}}, ${{NAME}}" {
+		t.Errorf("Expected input message to list the placeholders, got %q", prompt)
+	}
+	expectedFormula := `AND(ISNUMBER(FIND("${{COUNT}}",C3)),ISNUMBER(FIND("${{NAME}}",C3)))`
+	if dataValidations[0].Formula1 != expectedFormula {
+		t.Errorf("Expected formula %s, got %s", expectedFormula, dataValidations[0].Formula1)
+	}
+
+	conditionalFormats, err := workbook.GetConditionalFormats(defaultSheetName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conditionalFormats) != 4 || len(conditionalFormats["C3:C4 C6"]) != 1 || len(conditionalFormats["C5"]) != 1 {
+		t.Errorf("Expected cells sharing placeholders to share a conditional format, got %v", conditionalFormats)
+	}
+	if len(conditionalFormats["B2:B6"]) != 1 || len(conditionalFormats["D2:D6"]) != 1 {
+		t.Errorf("Expected translators' conditional formats to be kept, got %v", conditionalFormats)
+	}
+}
+
+func TestXlsx_Write_MaxLength(t *testing.T) {
+	xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx"))}
+
+	err := xlsxFile.Write(TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": "Send ${{COUNT}}", "fr": "Envoyer ${{COUNT}}"},
+			"key2": {"en": "value2", "fr": "valeur2"},
+		},
+		MessageInfos: KeyMessageInfoMap{
+			"key1": {MaxLength: 6},
+		},
+		SourceLocale:     "en",
+		NonSourceLocales: []Locale{"fr"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, err := excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer workbook.Close()
+
+	rows, err := workbook.GetRows(defaultSheetName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows[0][3] != maxLengthColumnLabel || rows[1][3] != "6" || len(rows[2]) > 3 {
+		t.Errorf("Expected max length column, got %v", rows)
+	}
+
+	styleID, _ := workbook.GetCellStyle(defaultSheetName, "C2")
+	if style, _ := workbook.GetStyle(styleID); len(style.Fill.Color) != 1 || style.Fill.Color[0] != invalidFillColor {
+		t.Error("Expected too long translation to be highlighted as invalid")
+	}
+
+	dataValidations, err := workbook.GetDataValidations(defaultSheetName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedFormula := `AND(ISNUMBER(FIND("${{COUNT}}",C2)),LEN(SUBSTITUTE(C2,"${{COUNT}}",""))<=6)`
+	if len(dataValidations) != 1 || dataValidations[0].Formula1 != expectedFormula {
+		t.Errorf("Expected data validation with formula %s, got %v", expectedFormula, dataValidations)
+	}
+}
+
+func TestXlsx_Write_Summary(t *testing.T) {
+	xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx"))}
+	table := TranslationTable{
+		Translations: KeyLocaleValueMap{
+			"key1": {"en": "Hello world", "fr": "Bonjour le monde", "de": ""},
+			"key2": {"en": "Hello ${{NAME}}", "fr": "", "de": "Hallo"},
+			"key3": {"en": "Goodbye", "fr": "Au revoir", "de": "Tschüss"},
+		},
+		SourceLocale:      "en",
+		NonSourceLocales:  []Locale{"de", "fr"},
+		StaleTranslations: KeyLocalesMap{"key3": {"fr"}},
+	}
+
+	// Written twice, to check the summary is replaced.
+	for range 2 {
+		err := xlsxFile.Write(table)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	workbook, err := excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer workbook.Close()
+
+	if sheets := workbook.GetSheetList(); !reflect.DeepEqual(sheets, []string{defaultSheetName, summarySheetName, metadataSheetName}) {
+		t.Fatalf("Expected the summary after the translations, got %v", sheets)
+	}
+	rows, err := workbook.GetRows(summarySheetName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedRows := [][]string{
+		summaryHeader,
+		{"de", "3", "1", "0", "1", "0", "1", "33%", "3"},
+		{"fr", "3", "1", "0", "1", "1", "0", "33%", "2"},
+	}
+	if !reflect.DeepEqual(rows, expectedRows) {
+		t.Errorf("Expected rows %v, got %v", expectedRows, rows)
+	}
+}
+
+func TestXlsx_Write_ForeignSummary(t *testing.T) {
+	xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx"))}
+	workbook := excelize.NewFile()
+	_ = workbook.SetSheetRow(defaultSheetName, "A1", &[]string{keyColumnLabel, "en", "fr"})
+	_, _ = workbook.NewSheet(summarySheetName)
+	_ = workbook.SetCellStr(summarySheetName, "A1", "Notes of the translators")
+	err := workbook.SaveAs(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.Close()
+
+	_, _, err = xlsxFile.GetData([]Locale{"en", "fr"})
+	if err == nil {
+		t.Error("Expected workbook with a Summary sheet of translators to be refused")
+	}
+	err = xlsxFile.Write(TranslationTable{Translations: KeyLocaleValueMap{}, SourceLocale: "en", NonSourceLocales: []Locale{"fr"}})
+	if err == nil {
+		t.Error("Expected Summary sheet of translators not to be replaced")
+	}
+
+	// The Summary sheet of a workbook written by an older version, which recorded nothing.
+	workbook, err = excelize.OpenFile(string(xlsxFile.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.SetSheetRow(summarySheetName, "A1", &summaryHeader)
+	err = workbook.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = workbook.Close()
+
+	err = xlsxFile.Write(TranslationTable{Translations: KeyLocaleValueMap{}, SourceLocale: "en", NonSourceLocales: []Locale{"fr"}})
+	if err != nil {
+		t.Errorf("Expected Summary sheet of an older version to be replaced, got %s", err)
+	}
+}
+
+func TestXlsx_HasReadablePlaceholders(t *testing.T) {
+	xlsxFile := Xlsx{Path: Path(filepath.Join(t.TempDir(), "translations.xlsx"))}
+	if hasReadablePlaceholders, err := xlsxFile.HasReadablePlaceholders(); err != nil || hasReadablePlaceholders {
+		t.Errorf("Expected no readable placeholders without a workbook, got %t and %v", hasReadablePlaceholders, err)
+	}
+
+	for _, readablePlaceholders := range []bool{true, false} {
+		err := xlsxFile.Write(TranslationTable{
+			Translations:         KeyLocaleValueMap{},
+			SourceLocale:         "en",
+			NonSourceLocales:     []Locale{"fr"},
+			ReadablePlaceholders: readablePlaceholders,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hasReadablePlaceholders, err := xlsxFile.HasReadablePlaceholders(); err != nil || hasReadablePlaceholders != readablePlaceholders {
+			t.Errorf("Expected readable placeholders to be recorded as %t, got %t and %v", readablePlaceholders, hasReadablePlaceholders, err)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	validationPromptTitle = "Translation rules"
+	// Excel refuses longer formulas in data validations, and longer messages.
+	maxDataValidationFormulaLength = 255
+	maxDataValidationPromptLength  = 255
+)
+
+// The parts of the criteria of the conditional formats added by addValidations.
+const (
+	formulaCellRegex                 = `[A-Z]+[0-9]+`
+	formulaTextRegex                 = `"(?:[^"]|"")*"`
+	placeholderConditionFormulaRegex = `ISNUMBER\(FIND\(` + formulaTextRegex + `,` + formulaCellRegex + `\)\)`
+	maxLengthConditionFormulaRegex   = `LEN\((?:SUBSTITUTE\()*` + formulaCellRegex + `(?:,` + formulaTextRegex + `,""\))*\)<=[0-9]+`
+	conditionFormulaRegex            = `(?:` + placeholderConditionFormulaRegex + `|` + maxLengthConditionFormulaRegex + `)`
+	// generatedConditionalFormatRegex e.g. AND(C2<>"",NOT(AND(ISNUMBER(FIND("${{NAME}}",C2)),LEN(SUBSTITUTE(C2,"${{NAME}}",""))<=12))).
+	generatedConditionalFormatRegex = `^AND\(` + formulaCellRegex + `<>"",NOT\(AND\(` + conditionFormulaRegex + `(?:,` + conditionFormulaRegex + `)*\)\)\)$`
+)
+
+// Escapes a formula of a data validation, as excelize writes it as is in the XML of the sheet.
+var dataValidationFormulaEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Flag, inside Excel itself, the translation cells missing any of the placeholders of their source string, or exceeding their maximum length.
+// The cells of a column sharing the same rules get an input message listing them, a data validation warning when one is broken,
+// and a conditional format, as data validations are only checked when typing; their formulas are relative to the first of these cells.
+// What was added by a previous run is replaced, so it follows the rows and columns.
+func addValidations(workbook *excelize.File, worksheetName string, rows [][]string, columns []int, rowNumbers []int, table TranslationTable) error {
+	err := removeValidations(workbook, worksheetName)
+	if err != nil {
+		return err
+	}
+
+	formatID, err := workbook.NewConditionalStyle(&excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{invalidFillColor}},
+	})
+	if err != nil {
+		return err
+	}
+
+	for j, label := range rows[0] {
+		if j == 0 || !slices.Contains(table.NonSourceLocales, Locale(label)) {
+			continue
+		}
+
+		var rules []validationRule
+		ruleRowNumbers := map[string][]int{}
+		for i, row := range rows[1:] {
+			key := Key(row[0])
+			placeholderIDs := slices.Compact(slices.Sorted(slices.Values(GetPlaceholderIDs(table.Translations[key][table.SourceLocale]))))
+			rule := validationRule{maxLength: table.MessageInfos[key].MaxLength}
+			if len(placeholderIDs) == 0 && rule.maxLength == 0 {
+				continue
+			}
+			for _, placeholderID := range placeholderIDs {
+				rule.placeholders = append(rule.placeholders, table.getDisplayedPlaceholder(key, placeholderID))
+			}
+
+			ruleID := rule.getID()
+			if _, ok := ruleRowNumbers[ruleID]; !ok {
+				rules = append(rules, rule)
+			}
+			ruleRowNumbers[ruleID] = append(ruleRowNumbers[ruleID], rowNumbers[i+1])
+		}
+
+		for _, rule := range rules {
+			err = addValidation(workbook, worksheetName, rule, columns[j]+1, ruleRowNumbers[rule.getID()], formatID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// The rules a translation must follow, shared by the cells of a column getting the same validation.
+type validationRule struct {
+	placeholders []string
+	maxLength    int
+}
+
+func (r validationRule) getID() string {
+	return strings.Join(r.placeholders, "\x00") + "\x00" + strconv.Itoa(r.maxLength)
+}
+
+// Add the data validation and the conditional format of a rule to the given cells of a column.
+func addValidation(workbook *excelize.File, worksheetName string, rule validationRule, column int, rowNumbers []int, formatID int) error {
+	slices.Sort(rowNumbers)
+	sqref, err := getColumnSqref(column, rowNumbers)
+	if err != nil {
+		return err
+	}
+	// Formulas are relative to the top left cell of the first range.
+	cellAddress, err := excelize.CoordinatesToCellName(column, rowNumbers[0])
+	if err != nil {
+		return err
+	}
+
+	var conditions, prompts []string
+	for _, placeholder := range rule.placeholders {
+		conditions = append(conditions, fmt.Sprintf(`ISNUMBER(FIND("%s",%s))`, escapeFormulaString(placeholder), cellAddress))
+	}
+	if len(rule.placeholders) > 0 {
+		prompts = append(prompts, "The translation must contain "+strings.Join(rule.placeholders, ", "))
+	}
+	if rule.maxLength > 0 {
+		conditions = append(conditions, fmt.Sprintf("LEN(%s)<=%d", getWithoutPlaceholdersFormula(cellAddress, rule.placeholders), rule.maxLength))
+		prompts = append(prompts, "The translation must be at most "+strconv.Itoa(rule.maxLength)+" characters long, placeholders excluded")
+	}
+	formula := "AND(" + strings.Join(conditions, ",") + ")"
+
+	dataValidation := excelize.NewDataValidation(true)
+	dataValidation.SetSqref(sqref)
+	dataValidation.SetInput(validationPromptTitle, truncate(strings.Join(prompts, "\n"), maxDataValidationPromptLength))
+	// Too many placeholders for a data validation; the conditional format still flags the cells.
+	if len(formula) <= maxDataValidationFormulaLength {
+		dataValidation.Type = "custom"
+		dataValidation.Formula1 = dataValidationFormulaEscaper.Replace(formula)
+		dataValidation.SetError(excelize.DataValidationErrorStyleWarning, "Broken translation rules",
+			"The translation is missing some of the placeholders of the source string, or is too long.")
+	}
+	err = workbook.AddDataValidation(worksheetName, dataValidation)
+	if err != nil {
+		return err
+	}
+
+	return workbook.SetConditionalFormat(worksheetName, sqref, []excelize.ConditionalFormatOptions{{
+		Type:     "formula",
+		Criteria: fmt.Sprintf(`AND(%s<>"",NOT(%s))`, cellAddress, formula),
+		Format:   &formatID,
+	}})
+}
+
+// The ranges covering the given sorted rows of a column, separated by spaces; e.g. C2:C5 C9.
+func getColumnSqref(column int, rowNumbers []int) (string, error) {
+	var ranges []string
+	for i := 0; i < len(rowNumbers); {
+		end := i
+		for end+1 < len(rowNumbers) && rowNumbers[end+1] == rowNumbers[end]+1 {
+			end++
+		}
+
+		rangeRef, err := excelize.CoordinatesToCellName(column, rowNumbers[i])
+		if err != nil {
+			return "", err
+		}
+		if end > i {
+			endCellAddress, err := excelize.CoordinatesToCellName(column, rowNumbers[end])
+			if err != nil {
+				return "", err
+			}
+			rangeRef += ":" + endCellAddress
+		}
+		ranges = append(ranges, rangeRef)
+		i = end + 1
+	}
+
+	return strings.Join(ranges, " "), nil
+}
+
+// A formula giving the text of a cell without the given placeholders; e.g. SUBSTITUTE(C2,"${{NAME}}","").
+func getWithoutPlaceholdersFormula(cellAddress string, placeholders []string) string {
+	formula := cellAddress
+	for _, placeholder := range placeholders {
+		formula = fmt.Sprintf(`SUBSTITUTE(%s,"%s","")`, formula, escapeFormulaString(placeholder))
+	}
+
+	return formula
+}
+
+func escapeFormulaString(text string) string {
+	return strings.ReplaceAll(text, `"`, `""`)
+}
+
+// Remove the data validations and conditional formats added by a previous run, if any.
+func removeValidations(workbook *excelize.File, worksheetName string) error {
+	dataValidations, err := workbook.GetDataValidations(worksheetName)
+	if err != nil {
+		return err
+	}
+	for _, dataValidation := range dataValidations {
+		if dataValidation.PromptTitle == nil || *dataValidation.PromptTitle != validationPromptTitle {
+			continue
+		}
+		err = workbook.DeleteDataValidation(worksheetName, dataValidation.Sqref)
+		if err != nil {
+			return err
+		}
+	}
+
+	conditionalFormats, err := workbook.GetConditionalFormats(worksheetName)
+	if err != nil {
+		return err
+	}
+	for rangeRef, options := range conditionalFormats {
+		if !slices.ContainsFunc(options, isGeneratedConditionalFormat) {
+			continue
+		}
+		err = workbook.UnsetConditionalFormat(worksheetName, rangeRef)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Whether a conditional format is one added by addValidations, matching the whole of its criteria, so the ones of translators are kept.
+func isGeneratedConditionalFormat(options excelize.ConditionalFormatOptions) bool {
+	return options.Type == "formula" && regexp.MustCompile(generatedConditionalFormatRegex).MatchString(options.Criteria)
+}
+
+// Shorten a text to the given number of characters, ending it with an ellipsis.
+func truncate(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+
+	return string(runes[:maxLength-1]) + "…"
+}
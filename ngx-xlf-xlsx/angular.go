@@ -5,6 +5,7 @@ import (
 	"errors"
 	"maps"
 	"os"
+	"slices"
 
 	. "common"
 )
@@ -73,6 +74,9 @@ func (p *Project) getNonSourceLocales() []Locale {
 		locales = append(locales, locale)
 	}
 
+	// Keep a stable order, so the columns of the translations file do not move around between runs.
+	slices.Sort(locales)
+
 	return locales
 }
 
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+
+	. "common"
+)
+
+const (
+	ToolConfigPath = "./ngx-xlf-xlsx.json"
+)
+
+// ToolConfig Settings of the tool for the project, next to angular.json; the file is optional.
+type ToolConfig struct {
+	// MaxLengths The maximum number of characters of the translations of some keys; e.g. the ones of buttons and SMS messages.
+	// These take precedence over the ones declared in the description of the messages.
+	MaxLengths map[Key]int `json:"maxLengths"`
+	// Rules How each validation rule is applied, by rule ID; e.g. to disable one, or to make its issues errors.
+	Rules map[string]RuleConfig `json:"rules"`
+	// Normalization How translations are cleaned up; e.g. which steps to skip, and the quotes of each locale.
+	Normalization NormalizationConfig `json:"normalization"`
+	// Fallbacks The locale each regional variant falls back to, for the messages it has no translation of its own for; e.g. "fr" for "fr-BE".
+	Fallbacks LocaleFallbacks `json:"fallbacks"`
+}
+
+func getToolConfig() (ToolConfig, error) {
+	var toolConfig ToolConfig
+
+	fileContent, err := os.ReadFile(ToolConfigPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return toolConfig, nil
+	}
+	if err != nil {
+		return toolConfig, err
+	}
+
+	err = json.Unmarshal(fileContent, &toolConfig)
+
+	return toolConfig, err
+}
@@ -1,16 +1,47 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
 	"log"
+	"maps"
+	"path/filepath"
+	"slices"
 	"strconv"
 
 	. "common"
+	"github.com/fatih/color"
 )
 
-var version string
+var (
+	version              string
+	translationsPath     = flag.String("file", XlsxPath, "file holding the translations; either a .xlsx, .ods, .csv, or .tsv file")
+	poDir                = flag.String("po", "", "exchange translations through gettext PO files in the given directory, instead of the translations file")
+	sheetPerLocale       = flag.Bool("sheet-per-locale", false, "write each non-source locale in a sheet of its own, in xlsx files")
+	split                = flag.Bool("split", false, "also write a workbook per non-source locale, next to the translations file, to be sent to translators")
+	mergePattern         = flag.String("merge", "", "merge back the per-locale workbooks returned by translators, matching the given glob pattern")
+	reportPath           = flag.String("report", "", "write all the issues found, and the number of translations changed per locale, to the given JSON file")
+	sarifPath            = flag.String("sarif", "", "write all the issues found to the given SARIF file, pointing at the lines of the xlf files")
+	junitPath            = flag.String("junit", "", "write all the issues found to the given JUnit XML file, with a test case per locale")
+	readablePlaceholders = flag.Bool("readable-placeholders", false, "show placeholders in spreadsheets as what they stand for, e.g. <b> or {{ user.name }}, instead of their ID")
+	// command What to do instead of updating the files, if anything; e.g. "stats".
+	command string
+)
 
 func main() {
 	log.SetFlags(0)
+	flag.Parse()
+
+	command = flag.Arg(0)
+	switch command {
+	case "":
+	case statsCommand:
+		_ = statsFlags.Parse(flag.Args()[1:])
+	default:
+		log.Fatalf("unknown command %s", strconv.Quote(command))
+	}
 
 	log.Println("================================")
 	log.Println("ngx-xlf-xlsx - " + version)
@@ -21,12 +52,20 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	if command == statsCommand {
+		return
+	}
 
 	log.Println("")
 	log.Println("================================")
 	log.Println("Done!")
-	log.Println("Excel file is at:")
-	log.Printf("%s\n", XlsxPath)
+	if *poDir != "" {
+		log.Println("PO files are in:")
+		log.Printf("%s\n", *poDir)
+	} else {
+		log.Println("Translations file is at:")
+		log.Printf("%s\n", *translationsPath)
+	}
 	log.Println("================================")
 }
 
@@ -34,6 +73,8 @@ func main() {
 // instead of using `log.Fatal` everywhere.
 func steps() error {
 	translationManager := TranslationManager{}
+	// Everything found during the run, written to the -report file, if any.
+	report := &Report{Changes: map[Locale]LocaleChanges{}}
 
 	log.Println("[1/6]\tReading Angular project configuration")
 	mainProject, err := getMainAngularProject()
@@ -47,6 +88,7 @@ func steps() error {
 	for _, locale := range nonSourceLocales {
 		translationManager.EnsureLocale(locale)
 	}
+	addIssues(report, getLocaleIssues(sourceLocale, nonSourceLocales)...)
 
 	log.Println("[2/6]\tReading source xlf file")
 	sourceXlfPath := mainProject.getLocalesMap()[sourceLocale]
@@ -55,27 +97,103 @@ func steps() error {
 		return err
 	}
 	sourceStringsMap := sourceXlf.getKeyValues()
+	if sourceXlf.File.SourceLanguage != sourceLocale {
+		addIssues(report, getXlfLanguageIssue(sourceXlfPath, sourceXlf.File.SourceLanguage, sourceLocale))
+	}
+
+	toolConfig, err := getToolConfig()
+	if err != nil {
+		return err
+	}
+	messageInfos := sourceXlf.getMessageInfos()
+	for key, maxLength := range toolConfig.MaxLengths {
+		messageInfo, ok := messageInfos[key]
+		if !ok {
+			continue
+		}
+		messageInfo.MaxLength = maxLength
+		messageInfos[key] = messageInfo
+	}
+	for key, messageInfo := range messageInfos {
+		if messageInfo.MaxLength > 0 {
+			translationManager.SetMaxLength(key, messageInfo.MaxLength)
+		}
+	}
+
+	normalizer, err := NewNormalizer(toolConfig.Normalization)
+	if err != nil {
+		return err
+	}
+	translationManager.SetNormalizer(normalizer)
+	err = translationManager.SetFallbacks(toolConfig.Fallbacks)
+	if err != nil {
+		return err
+	}
 
 	err = translationManager.AddTranslations(sourceStringsMap, sourceLocale)
 	if err != nil {
 		return err
 	}
 
-	log.Println("[3/6]\tEnsuring xlsx file exists")
-	xlsxFile := Xlsx{}
-	err = xlsxFile.EnsureExists(sourceLocale, nonSourceLocales)
+	translationStore, err := getTranslationStore()
 	if err != nil {
 		return err
 	}
 
-	log.Println("[4/6]\tReading xlsx file")
-	xlsxData, err := xlsxFile.GetData()
+	// Stats are only read, so nothing is written, not even an empty translations file.
+	if command != statsCommand {
+		log.Println("[3/6]\tEnsuring translations file exists")
+		err = translationStore.EnsureExists(sourceLocale, nonSourceLocales)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Println("[4/6]\tReading translations file")
+	storedData, tableProblems, err := translationStore.GetData(append([]Locale{sourceLocale}, nonSourceLocales...))
+	if command == statsCommand && errors.Is(err, fs.ErrNotExist) {
+		storedData, err = KeyLocaleValueMap{}, nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, tableProblem := range tableProblems {
+		addIssues(report, getTableProblemIssue(tableProblem))
+	}
+	// The translations file is read the way it was written, which may not be the way it is written by this run.
+	if store, ok := translationStore.(ReadablePlaceholdersStore); ok {
+		hasReadablePlaceholders, err := store.HasReadablePlaceholders()
+		if err != nil {
+			return err
+		}
+		if hasReadablePlaceholders {
+			var issues []Issue
+			storedData, issues = ParseReadablePlaceholders(storedData, sourceStringsMap, messageInfos)
+			addIssues(report, issues...)
+		}
+	}
+
+	previousXlfs, err := getPreviousXlfs(mainProject, nonSourceLocales)
 	if err != nil {
 		return err
 	}
-	xlsxDataGrouped := xlsxData.GroupByLocale()
+	for _, locale := range nonSourceLocales {
+		// Xlf files written before target-language was set have none.
+		if previousXlf, ok := previousXlfs[locale]; ok && previousXlf.File.TargetLanguage != "" && previousXlf.File.TargetLanguage != locale {
+			addIssues(report, getXlfLanguageIssue(mainProject.getLocalesMap()[locale], previousXlf.File.TargetLanguage, locale))
+		}
+	}
+
+	previousSourceStrings := getPreviousSourceStrings(previousXlfs, sourceStringsMap)
+	for _, protectedEdit := range GetProtectedEdits(storedData, sourceLocale, previousSourceStrings, normalizer) {
+		addIssues(report, getProtectedEditIssue(protectedEdit))
+		if protectedEdit.IsUnknownKey {
+			delete(storedData, protectedEdit.Key)
+		}
+	}
+	storedDataGrouped := storedData.GroupByLocale()
 
-	for locale, keyValueMap := range xlsxDataGrouped {
+	for locale, keyValueMap := range storedDataGrouped {
 		if locale == sourceLocale {
 			continue
 		}
@@ -91,14 +209,70 @@ func steps() error {
 		}
 	}
 
-	log.Println("[5/6]\tWriting to xlsx file")
-	err = xlsxFile.Write(translationManager.GetExportableTranslations(), sourceLocale, nonSourceLocales)
+	if *mergePattern != "" {
+		err = mergeLocaleWorkbooks(&translationManager, report, TranslationTable{
+			Translations:     translationManager.GetExportableTranslations(),
+			MessageInfos:     messageInfos,
+			SourceLocale:     sourceLocale,
+			NonSourceLocales: nonSourceLocales,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	markStaleTranslations(&translationManager, previousXlfs, sourceStringsMap)
+
+	if command == statsCommand {
+		return printStats(translationManager.GetStats(getNewKeys(sourceStringsMap, storedData)))
+	}
+
+	// Normalizations of source strings already reported by the previous run, if any, are not reported again.
+	var reportedSourceStrings KeyValueMap
+	if len(previousXlfs) > 0 {
+		reportedSourceStrings = previousSourceStrings
+	}
+	for _, normalization := range GetNewNormalizations(translationManager.GetNormalizations(), reportedSourceStrings) {
+		addIssues(report, getNormalizationIssue(normalization))
+	}
+
+	table := TranslationTable{
+		Translations:         translationManager.GetExportableTranslations(),
+		MessageInfos:         messageInfos,
+		SourceLocale:         sourceLocale,
+		NonSourceLocales:     nonSourceLocales,
+		StaleTranslations:    translationManager.GetStaleTranslations(),
+		ReadablePlaceholders: isReadablePlaceholders(),
+		Fallbacks:            toolConfig.Fallbacks,
+	}
+
+	// All the translations are checked before anything is written.
+	// Invalid translations are still written, so they can be fixed in the translations file, but errors make the run fail.
+	validator, err := NewValidator(toolConfig.Rules)
 	if err != nil {
 		return err
 	}
+	addIssues(report, validator.Validate(table)...)
+
+	log.Println("[5/6]\tWriting to translations file")
+	err = translationStore.Write(table)
+	if err != nil {
+		return err
+	}
+
+	if *split {
+		paths, err := WriteLocaleWorkbooks(Path(*translationsPath), table)
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			log.Printf("\tWrote workbook %s\n", strconv.Quote(string(path)))
+		}
+	}
 
 	log.Println("[6/6]\tWriting xlf files")
-	translationsByLocale := translationManager.GetTranslationsByLocale()
+	// Locales with a fallback get the translations they inherit, as Angular knows nothing about fallbacks.
+	translationsByLocale := translationManager.GetResolvedTranslationsByLocale()
 	for _, locale := range translationManager.GetNonSourceLocales() {
 		log.Printf("\tWriting xlf file for locale %s\n", strconv.Quote(string(locale)))
 		localeXlfPath := mainProject.getLocalesMap()[locale]
@@ -106,7 +280,116 @@ func steps() error {
 		// Make a copy of the source xlf file.
 		// This is now the xlf file for the current locale.
 		localeXlf := sourceXlf
-		err = localeXlf.write(localeXlfPath, translations)
+		localeXlf.File.TargetLanguage = locale
+		err = localeXlf.write(localeXlfPath, translations, translationManager.GetStaleKeys(locale))
+		if err != nil {
+			return err
+		}
+
+		// Translations of keys that are not in the source xlf file anymore are not written.
+		writtenTranslations := KeyValueMap{}
+		for key := range sourceStringsMap {
+			writtenTranslations[key] = translations[key]
+		}
+		var previousTranslations KeyValueMap
+		if previousXlf, ok := previousXlfs[locale]; ok {
+			previousTranslations = previousXlf.getTargetValues()
+		}
+		report.Changes[locale] = GetLocaleChanges(previousTranslations, writtenTranslations)
+	}
+
+	translationsFilePath := Path(*translationsPath)
+	if *poDir != "" {
+		translationsFilePath = Path(*poDir)
+	}
+	err = locateIssues(report, mainProject, translationsFilePath)
+	if err != nil {
+		return err
+	}
+	if *reportPath != "" {
+		err = report.Write(Path(*reportPath))
+		if err != nil {
+			return err
+		}
+	}
+	if *sarifPath != "" {
+		err = report.WriteSarif(Path(*sarifPath), version)
+		if err != nil {
+			return err
+		}
+	}
+	if *junitPath != "" {
+		err = report.WriteJUnit(Path(*junitPath), nonSourceLocales)
+		if err != nil {
+			return err
+		}
+	}
+	if report.HasErrors() {
+		return fmt.Errorf("%s some translations or locales are invalid; see the errors above", color.RedString("[ERROR]"))
+	}
+
+	return nil
+}
+
+// The -po flag takes precedence over -file, since the latter always has a default value.
+func getTranslationStore() (TranslationStore, error) {
+	if *poDir != "" {
+		return &Po{Dir: Path(*poDir)}, nil
+	}
+
+	translationStore, err := NewTranslationStore(Path(*translationsPath), TranslationStoreOptions{
+		SheetPerLocale: *sheetPerLocale,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Reading such a file without the option would take the texts of the placeholders as text, so the file must record it.
+	if _, ok := translationStore.(ReadablePlaceholdersStore); *readablePlaceholders && !ok {
+		return nil, fmt.Errorf("-readable-placeholders is only supported by %s translations files, as others cannot record it", XlsxExtension)
+	}
+
+	return translationStore, nil
+}
+
+// Whether this run writes readable placeholders; PO files are not spreadsheets, and always have placeholders as their ID.
+func isReadablePlaceholders() bool {
+	return *readablePlaceholders && *poDir == ""
+}
+
+// Merge back the workbooks returned by translators.
+// All the workbooks are checked before any translation is added, so nothing is merged if any of them is refused.
+func mergeLocaleWorkbooks(translationManager *TranslationManager, report *Report, table TranslationTable) error {
+	paths, err := filepath.Glob(*mergePattern)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no workbook to merge matches %s", strconv.Quote(*mergePattern))
+	}
+
+	localeKeyValueMap := LocaleKeyValueMap{}
+	var workbookIssues []Issue
+	for _, path := range paths {
+		locale, keyValueMap, issues, err := ReadLocaleWorkbook(Path(path), table)
+		if err != nil {
+			return err
+		}
+		if _, ok := localeKeyValueMap[locale]; ok {
+			return fmt.Errorf("more than one workbook to merge for locale %s", strconv.Quote(string(locale)))
+		}
+		localeKeyValueMap[locale] = keyValueMap
+		workbookIssues = append(workbookIssues, issues...)
+	}
+	addIssues(report, workbookIssues...)
+
+	for _, locale := range table.NonSourceLocales {
+		keyValueMap, ok := localeKeyValueMap[locale]
+		if !ok {
+			continue
+		}
+
+		log.Printf("\tMerging workbook for locale %s\n", strconv.Quote(string(locale)))
+		err = translationManager.AddTranslations(keyValueMap, locale)
 		if err != nil {
 			return err
 		}
@@ -114,3 +397,66 @@ func steps() error {
 
 	return nil
 }
+
+// The xlf files of the non-source locales, as written by the previous run, if any.
+func getPreviousXlfs(mainProject Project, nonSourceLocales []Locale) (map[Locale]Xliff, error) {
+	previousXlfs := map[Locale]Xliff{}
+
+	for _, locale := range nonSourceLocales {
+		previousXlf, err := getPathXlf(mainProject.getLocalesMap()[locale])
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		previousXlfs[locale] = previousXlf
+	}
+
+	return previousXlfs, nil
+}
+
+// The source strings as they were when the translations file was last written, as every xlf file written then has them.
+// If the xlf files disagree, e.g. one of them was edited by hand, the source xlf file is used instead.
+func getPreviousSourceStrings(previousXlfs map[Locale]Xliff, sourceStringsMap KeyValueMap) KeyValueMap {
+	var previousSourceStrings KeyValueMap
+
+	for _, locale := range slices.Sorted(maps.Keys(previousXlfs)) {
+		previousXlf := previousXlfs[locale]
+		sourceStrings := previousXlf.getKeyValues()
+		if previousSourceStrings == nil {
+			previousSourceStrings = sourceStrings
+			continue
+		}
+		if !maps.Equal(sourceStrings, previousSourceStrings) {
+			log.Printf("\tSource strings of xlf file of locale %s differ from the other ones; using the source xlf file to find edited source strings\n",
+				strconv.Quote(string(locale)))
+			return sourceStringsMap
+		}
+	}
+	if previousSourceStrings == nil {
+		return sourceStringsMap
+	}
+
+	return previousSourceStrings
+}
+
+// The xlf files written by the previous run tell which translations were made for a source string that changed since.
+func markStaleTranslations(translationManager *TranslationManager, previousXlfs map[Locale]Xliff, sourceStringsMap KeyValueMap) {
+	translationsByLocale := translationManager.GetTranslationsByLocale()
+
+	for _, locale := range translationManager.GetNonSourceLocales() {
+		previousXlf, ok := previousXlfs[locale]
+		if !ok {
+			continue
+		}
+
+		staleKeys := previousXlf.getStaleKeys(sourceStringsMap, translationsByLocale[locale])
+		for _, key := range staleKeys {
+			translationManager.MarkStale(key, locale)
+		}
+		if len(staleKeys) > 0 {
+			log.Printf("\tFound %d stale translations for locale %s\n", len(staleKeys), strconv.Quote(string(locale)))
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	. "common"
+	"github.com/fatih/color"
+)
+
+// Log the issues as they are found, and keep them for the report.
+func addIssues(report *Report, issues ...Issue) {
+	for _, issue := range issues {
+		logIssue(issue)
+	}
+	report.Issues = append(report.Issues, issues...)
+}
+
+func logIssue(issue Issue) {
+	colorGrayString := color.RGB(128, 128, 128).SprintFunc()
+
+	label := color.YellowString("[WARN]")
+	switch issue.Severity {
+	case SeverityError:
+		label = color.RedString("[ERROR]")
+	case SeverityInfo:
+		label = color.BlueString("[INFO]")
+	}
+
+	location := "translations file"
+	if issue.File != "" {
+		location = strconv.Quote(string(issue.File))
+	}
+	if issue.Key != "" {
+		location = color.CyanString(strconv.Quote(string(issue.Key)))
+	}
+	if issue.Locale != "" {
+		location += fmt.Sprintf(" for locale %s", strconv.Quote(string(issue.Locale)))
+	}
+
+	message := fmt.Sprintf("%s in %s\n\t%s", label, location, issue.Message)
+	if issue.Source != "" {
+		message += "\n\tsource string was " + colorGrayString(strconv.Quote(string(issue.Source)))
+	}
+	if issue.Target != "" {
+		message += "\n\ttranslation was " + colorGrayString(strconv.Quote(string(issue.Target)))
+	}
+	log.Println(message)
+}
+
+// Keys and source strings are not meant to be edited by translators.
+// Edited source strings are restored, and rows with an unknown key are dropped.
+func getProtectedEditIssue(protectedEdit ProtectedEdit) Issue {
+	if protectedEdit.IsUnknownKey {
+		return Issue{
+			Severity: SeverityWarning,
+			Rule:     RuleEditedKey,
+			Key:      protectedEdit.Key,
+			Source:   protectedEdit.Actual,
+			Message:  "unknown key in translations file; keys are not meant to be edited, so the row is dropped",
+		}
+	}
+
+	return Issue{
+		Severity: SeverityWarning,
+		Rule:     RuleEditedSource,
+		Key:      protectedEdit.Key,
+		Source:   protectedEdit.Expected,
+		Message: fmt.Sprintf("source string was edited in translations file to %s; it is not meant to be edited, so it is restored",
+			strconv.Quote(string(protectedEdit.Actual))),
+	}
+}
+
+func getNormalizationIssue(normalization Normalization) Issue {
+	if normalization.IsSource {
+		return Issue{
+			Severity: SeverityInfo,
+			Rule:     RuleNormalization,
+			Key:      normalization.Key,
+			Source:   normalization.After,
+			Message: fmt.Sprintf("whitespaces of source string were normalized; it was %s; set the whitespace policy of the key to %s to keep them",
+				strconv.Quote(string(normalization.Before)), strconv.Quote(string(WhitespacePreserve))),
+		}
+	}
+
+	return Issue{
+		Severity: SeverityInfo,
+		Rule:     RuleNormalization,
+		Key:      normalization.Key,
+		Locale:   normalization.Locale,
+		Target:   normalization.After,
+		Message: fmt.Sprintf("translation was normalized (%s); it was %s",
+			strings.Join(normalization.Steps, ", "), strconv.Quote(string(normalization.Before))),
+	}
+}
+
+// Angular writes the locales in the xlf files, which must be the ones of angular.json.
+func getXlfLanguageIssue(path Path, xlfLocale Locale, locale Locale) Issue {
+	attribute, advice := "target-language", "it is rewritten"
+	if path == SourceXlfPath {
+		attribute, advice = "source-language", "extract the source strings again"
+	}
+
+	return Issue{
+		Severity: SeverityWarning,
+		Rule:     RuleLocale,
+		Locale:   locale,
+		Message: fmt.Sprintf("%s of xlf file is %s, not the locale of angular.json; %s",
+			attribute, strconv.Quote(string(xlfLocale)), advice),
+		File: path,
+	}
+}
+
+// The locales of angular.json are reported in it.
+func getLocaleIssues(sourceLocale Locale, nonSourceLocales []Locale) []Issue {
+	issues := ValidateLocales(sourceLocale, nonSourceLocales)
+	for i := range issues {
+		issues[i].File = AngularConfigPath
+	}
+
+	return issues
+}
+
+func getTableProblemIssue(tableProblem TableProblem) Issue {
+	return Issue{
+		Severity: SeverityWarning,
+		Rule:     RuleTranslationsFile,
+		Message:  tableProblem.String(),
+	}
+}
+
+// Point each issue at the line of its trans-unit, in the xlf file of its locale, or in the source xlf file.
+// Issues about the translations file point at it as a whole, and issues already pointing at a file are kept as is.
+func locateIssues(report *Report, mainProject Project, translationsFilePath Path) error {
+	linesByPath := map[Path]map[Key]int{}
+
+	for i, issue := range report.Issues {
+		if issue.File != "" {
+			continue
+		}
+		if issue.Key == "" || issue.Rule == RuleEditedKey {
+			report.Issues[i].File = translationsFilePath
+			continue
+		}
+
+		path := mainProject.getLocalesMap()[mainProject.getSourceLocale()]
+		if issue.Locale != "" {
+			path = mainProject.getLocalesMap()[issue.Locale]
+		}
+
+		lines, ok := linesByPath[path]
+		if !ok {
+			var err error
+			lines, err = getTransUnitLines(path)
+			if err != nil {
+				return err
+			}
+			linesByPath[path] = lines
+		}
+
+		report.Issues[i].File = path
+		report.Issues[i].Line = lines[issue.Key]
+	}
+
+	return nil
+}
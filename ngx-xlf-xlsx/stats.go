@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	. "common"
+)
+
+const (
+	statsCommand = "stats"
+)
+
+var (
+	statsFlags = flag.NewFlagSet(statsCommand, flag.ExitOnError)
+	statsJson  = statsFlags.Bool("json", false, "print the stats as JSON")
+)
+
+// The keys of the source strings that were not in the translations file yet.
+func getNewKeys(sourceStringsMap KeyValueMap, storedData KeyLocaleValueMap) []Key {
+	var keys []Key
+
+	for key := range sourceStringsMap {
+		if _, ok := storedData[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// Print the stats on the standard output, so they can be piped, while the progress is logged on the standard error.
+func printStats(stats []LocaleStats) error {
+	if *statsJson {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(stats)
+	}
+
+	for _, localeStats := range stats {
+		fmt.Printf("Locale %s\n", strconv.Quote(string(localeStats.Locale)))
+		fmt.Printf("  %-16s %8s %8s\n", "", "messages", "words")
+		for _, row := range []struct {
+			label string
+			count StatsCount
+		}{
+			{"repeated", localeStats.Repeated},
+			{"fuzzy-matchable", localeStats.FuzzyMatchable},
+			{"new", localeStats.New},
+			{"untranslated", localeStats.Untranslated},
+			{"total", localeStats.Total},
+		} {
+			fmt.Printf("  %-16s %8d %8d\n", row.label, row.count.Messages, row.count.Words)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
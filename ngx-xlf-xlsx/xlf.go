@@ -1,26 +1,40 @@
 package main
 
 import (
+	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"maps"
 	"os"
 	"regexp"
 	"slices"
 	"strconv"
+	"strings"
 
 	. "common"
-	"github.com/fatih/color"
 )
 
 const (
 	placeholderInValueRegex = `<x[\s\t\n\r]*[\s\S]*?id="([\s\S]*?)"[\s\S]*?(?:\/>|>[\s\t\n\r]*<\/x>)`
-	placeholderInTextRegex  = `\$\{\{([\s\S]*?)\}\}`
-	placeholderSprintf      = "${{%s}}"
 	defaultFilePermissions  = 0600
 	unmarshalStringFormat   = "<root>%s</root>"
+
+	// Translations whose source string changed since they were made; kept across runs in the xlf files.
+	targetStateNeedsReview = "needs-review-translation"
+
+	noteFromDescription         = "description"
+	noteFromMeaning             = "meaning"
+	contextGroupPurposeLocation = "location"
+	contextTypeSourceFile       = "sourcefile"
+	contextTypeLineNumber       = "linenumber"
 )
 
+// Escapes text to be written as raw XML; line breaks and quotes are kept as is, as in the xlf files written by Angular.
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
 type Xliff struct {
 	XMLName struct{} `xml:"xliff"`
 	File    File     `xml:"file"`
@@ -33,6 +47,7 @@ type File struct {
 		TransUnits []TransUnit `xml:"trans-unit"`
 	} `xml:"body"`
 	SourceLanguage Locale `xml:"source-language,attr"`
+	TargetLanguage Locale `xml:"target-language,attr,omitempty"` // Only set in the xlf files of the non-source locales.
 	DataType       string `xml:"datatype,attr"`
 	Original       string `xml:"original,attr"`
 }
@@ -44,19 +59,27 @@ type TransUnit struct {
 	SourceStr    string         `xml:"-"` // Same as Source, but with all the placeholders replaced with their string representation.
 	X            []X            `xml:"-"` // Placeholders found in the Source string.
 	ContextGroup []ContextGroup `xml:"context-group"`
+	Notes        []Note         `xml:"note"`
 	Target       SourceTarget   `xml:"target,omitempty"`
+	TargetStr    string         `xml:"-"` // Same as SourceStr, but for Target.
 }
 
 // SourceTarget Must use a dedicated struct as we cannot use tag `xml:",innerxml"` together with `xml:"source"` or `xml:"target"`.
 type SourceTarget struct {
 	InnerXML string `xml:",innerxml"`
+	State    string `xml:"state,attr,omitempty"` // Only used for targets.
 }
 
 // X A placeholder inside a source or target element.
+// All its attributes are kept, so the placeholders of targets are the same as the ones of the source.
 type X struct {
 	XMLName   xml.Name `xml:"x"`
 	ID        string   `xml:"id,attr"`
-	EquivText string   `xml:"equiv-text,attr"`
+	CType     string   `xml:"ctype,attr,omitempty"` // The kind of element, for element placeholders; e.g. "x-b" for START_BOLD_TEXT.
+	EquivText string   `xml:"equiv-text,attr,omitempty"`
+	XID       string   `xml:"xid,attr,omitempty"`
+	// OtherAttrs Attributes not listed above, in case Angular adds some.
+	OtherAttrs []xml.Attr `xml:",any,attr"`
 }
 
 type ContextGroup struct {
@@ -67,6 +90,13 @@ type ContextGroup struct {
 	} `xml:"context"`
 }
 
+// Note A note left by developers for translators; e.g. the description or the meaning of a message.
+type Note struct {
+	Priority string `xml:"priority,attr,omitempty"`
+	From     string `xml:"from,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
 func getPathXlf(path Path) (Xliff, error) {
 	xlf := Xliff{}
 	err := xlf.read(path)
@@ -110,17 +140,62 @@ func (x *Xliff) getKeyValues() KeyValueMap {
 	return keyValueMap
 }
 
-func (x *Xliff) write(path Path, translations KeyValueMap) error {
-	for key, value := range translations {
+// The translations in the xlf file of a non-source locale, with the placeholders replaced with their string representation.
+func (x *Xliff) getTargetValues() KeyValueMap {
+	keyValueMap := KeyValueMap{}
+
+	for _, transUnit := range x.File.Body.TransUnits {
+		keyValueMap[transUnit.ID] = Value(transUnit.TargetStr)
+	}
+
+	return keyValueMap
+}
+
+func (x *Xliff) getMessageInfos() KeyMessageInfoMap {
+	keyMessageInfoMap := KeyMessageInfoMap{}
+
+	for _, transUnit := range x.File.Body.TransUnits {
+		keyMessageInfoMap[transUnit.ID] = transUnit.getMessageInfo()
+	}
+
+	return keyMessageInfoMap
+}
+
+// Translations whose source string changed since they were made, and that were not updated since.
+// The xlf file is expected to be the one of a non-source locale, as written by a previous run.
+func (x *Xliff) getStaleKeys(sourceStrings KeyValueMap, translations KeyValueMap) []Key {
+	var keys []Key
+
+	for _, transUnit := range x.File.Body.TransUnits {
+		translation := translations[transUnit.ID]
+		if translation == "" || translation != Value(transUnit.TargetStr) {
+			// The translation was made, or updated, for the current source string.
+			continue
+		}
+
+		if transUnit.Target.State == targetStateNeedsReview || Value(transUnit.SourceStr) != sourceStrings[transUnit.ID] {
+			keys = append(keys, transUnit.ID)
+		}
+	}
+
+	return keys
+}
+
+// Write the xlf file of a non-source locale, with the given translations.
+func (x *Xliff) write(path Path, translations KeyValueMap, staleKeys []Key) error {
+	for _, key := range slices.Sorted(maps.Keys(translations)) {
 		index := slices.IndexFunc(x.File.Body.TransUnits, func(transUnit TransUnit) bool { return transUnit.ID == key })
 		if index == -1 {
 			// If Excel file has additional keys that are not in the source xlf file, we ignore them.
 			continue
 		}
 
-		err := x.File.Body.TransUnits[index].setTarget(value)
-		if err != nil {
-			return err
+		x.File.Body.TransUnits[index].setTarget(translations[key])
+
+		// Keep track of stale translations for the next runs.
+		x.File.Body.TransUnits[index].Target.State = ""
+		if slices.Contains(staleKeys, key) {
+			x.File.Body.TransUnits[index].Target.State = targetStateNeedsReview
 		}
 	}
 
@@ -136,26 +211,19 @@ func (x *Xliff) write(path Path, translations KeyValueMap) error {
 }
 
 func (tu *TransUnit) fixRead() error {
-	// Replace placeholders in the source string with their string representation.
-	regex := regexp.MustCompile(placeholderInValueRegex)
-	sourceStr := regex.ReplaceAllStringFunc(tu.Source.InnerXML, func(placeholder string) string {
-		placeholderMatch := regex.FindStringSubmatch(placeholder)
-		if placeholderMatch == nil {
-			log.Fatalf("could not find and replace placeholder %s in value %s", strconv.Quote(placeholder), strconv.Quote(tu.Source.InnerXML))
-		}
-
-		placeholderId := placeholderMatch[1]
-
-		return fmt.Sprintf(placeholderSprintf, placeholderId)
-	})
-
-	// Since the source string was raw XML, we need to unescape it ourselves.
-	sourceStr, err := unescape(sourceStr)
+	sourceStr, err := toTextString(tu.Source.InnerXML)
 	if err != nil {
 		return err
 	}
 	tu.SourceStr = sourceStr
 
+	// Only the xlf files of non-source locales have a target.
+	targetStr, err := toTextString(tu.Target.InnerXML)
+	if err != nil {
+		return err
+	}
+	tu.TargetStr = targetStr
+
 	// Extract placeholders from the source string.
 	placeholders, err := extractPlaceholdersFromXMLString(tu.Source.InnerXML)
 	if err != nil {
@@ -166,49 +234,61 @@ func (tu *TransUnit) fixRead() error {
 	return nil
 }
 
-func (tu *TransUnit) setTarget(value Value) error {
-	colorGrayString := color.RGB(128, 128, 128).SprintFunc()
-
-	placeholderIDs := extractPlaceholderIDsFromTextString(string(value))
-	placeholderCount := len(placeholderIDs)
-
-	// Ensure number of placeholders is the same in source and target.
-	if placeholderCount != len(tu.X) {
-		log.Printf("%s in %s\n"+
-			"\tplaceholder count in translation does not match placeholder count in source string.\n"+
-			"\tsource had %s (%v) but translation has %s (%v).\n"+
-			"\tsource string was %s",
-			color.YellowString("[WARN]"),
-			color.CyanString(strconv.Quote(string(tu.ID))),
-			color.MagentaString(strconv.Itoa(len(tu.X))), tu.X,
-			color.RedString(strconv.Itoa(placeholderCount)), placeholderIDs,
-			colorGrayString(strconv.Quote(tu.SourceStr)))
-	}
+func (tu *TransUnit) getMessageInfo() MessageInfo {
+	messageInfo := MessageInfo{PlaceholderTexts: map[string]string{}}
 
-	// Ensure all placeholders in source are present in target.
 	for _, x := range tu.X {
-		contains := slices.ContainsFunc(placeholderIDs, func(placeHolderId string) bool { return placeHolderId == x.ID })
-		if !contains {
-			log.Printf("%s in %s\n"+
-				"\tplaceholder %s present in source string is missing from translation %s",
-				color.YellowString("[WARN]"),
-				color.CyanString(strconv.Quote(string(tu.ID))),
-				color.MagentaString(strconv.Quote(x.ID)),
-				colorGrayString(strconv.Quote(string(value))))
+		messageInfo.PlaceholderTexts[x.ID] = x.EquivText
+	}
+
+	for _, note := range tu.Notes {
+		switch note.From {
+		case noteFromDescription:
+			messageInfo.Description = note.Value
+			messageInfo.MaxLength = GetMaxLengthFromDescription(note.Value)
+		case noteFromMeaning:
+			messageInfo.Meaning = note.Value
 		}
 	}
 
-	targetStr := emplacePlaceholders(tu, value)
-	tu.Target.InnerXML = targetStr
+	for _, contextGroup := range tu.ContextGroup {
+		if contextGroup.Purpose != contextGroupPurposeLocation {
+			continue
+		}
 
-	return nil
+		var sourceFile, lineNumber string
+		for _, context := range contextGroup.Context {
+			switch context.ContextType {
+			case contextTypeSourceFile:
+				sourceFile = context.Value
+			case contextTypeLineNumber:
+				lineNumber = context.Value
+			}
+		}
+		if sourceFile == "" {
+			continue
+		}
+		if lineNumber != "" {
+			sourceFile += ":" + lineNumber
+		}
+		messageInfo.Locations = append(messageInfo.Locations, sourceFile)
+	}
+
+	return messageInfo
+}
+
+// Set the target of the trans-unit to the given translation, with its placeholders as XML tags.
+// Translations are checked beforehand, by the validator.
+func (tu *TransUnit) setTarget(value Value) {
+	tu.Target.InnerXML = emplacePlaceholders(tu, value)
 }
 
 // Replace, in a string, the string representation of placeholders by a corresponding XML tags.
 // Original placeholders are re-used if found, otherwise a new one are created.
 func emplacePlaceholders(tu *TransUnit, value Value) string {
-	regex := regexp.MustCompile(placeholderInTextRegex)
-	valueStr := regex.ReplaceAllStringFunc(string(value), func(placeholder string) string {
+	regex := regexp.MustCompile(PlaceholderInTextRegex)
+	// The value is text, written as raw XML; placeholders are not changed by escaping.
+	valueStr := regex.ReplaceAllStringFunc(textEscaper.Replace(string(value)), func(placeholder string) string {
 		result := regex.FindStringSubmatch(placeholder)
 		if result == nil {
 			log.Fatalf("could not find and replace placeholder %s in value %s", strconv.Quote(placeholder), strconv.Quote(string(value)))
@@ -219,10 +299,6 @@ func emplacePlaceholders(tu *TransUnit, value Value) string {
 		var placeholderObj X
 		index := slices.IndexFunc(tu.X, func(x X) bool { return x.ID == placeholderId })
 		if index == -1 {
-			log.Printf("%s in %s\n"+
-				"\tcould not find corresponding placeholder in source string; created a made up one",
-				color.YellowString("[WARN]"),
-				color.CyanString(strconv.Quote(string(tu.ID))))
 			placeholderObj = X{
 				ID:        placeholderId,
 				EquivText: placeholderId,
@@ -242,6 +318,24 @@ func emplacePlaceholders(tu *TransUnit, value Value) string {
 	return valueStr
 }
 
+// Replace, in raw XML, the placeholders with their string representation, and unescape the rest.
+func toTextString(innerXML string) (string, error) {
+	regex := regexp.MustCompile(placeholderInValueRegex)
+	str := regex.ReplaceAllStringFunc(innerXML, func(placeholder string) string {
+		placeholderMatch := regex.FindStringSubmatch(placeholder)
+		if placeholderMatch == nil {
+			log.Fatalf("could not find and replace placeholder %s in value %s", strconv.Quote(placeholder), strconv.Quote(innerXML))
+		}
+
+		placeholderId := placeholderMatch[1]
+
+		return fmt.Sprintf(PlaceholderSprintf, placeholderId)
+	})
+
+	// Since the string was raw XML, we need to unescape it ourselves.
+	return unescape(str)
+}
+
 func extractPlaceholdersFromXMLString(str string) ([]X, error) {
 	// This struct is only used to unmarshal the placeholders from the raw string in SourceTarget.Value.
 	type Tmp struct {
@@ -257,21 +351,6 @@ func extractPlaceholdersFromXMLString(str string) ([]X, error) {
 	return tmp.X, nil
 }
 
-// Extract the ID of the placeholders from a string that might contain some.
-func extractPlaceholderIDsFromTextString(str string) []string {
-	regex := regexp.MustCompile(placeholderInTextRegex)
-	matches := regex.FindAllStringSubmatch(str, -1)
-	if matches == nil {
-		matches = [][]string{}
-	}
-	var results []string
-	for _, placeholderFoundArr := range matches {
-		results = append(results, placeholderFoundArr[1])
-	}
-
-	return results
-}
-
 func unescape(str string) (string, error) {
 	type Tmp struct {
 		Text string `xml:",chardata"`
@@ -288,3 +367,37 @@ func unescape(str string) (string, error) {
 func (x X) String() string {
 	return x.ID
 }
+
+// The line of each trans-unit of an xlf file, for reports to point at them.
+func getTransUnitLines(path Path) (map[Key]int, error) {
+	fileContent, err := os.ReadFile(string(path))
+	if err != nil {
+		return nil, err
+	}
+
+	lines := map[Key]int{}
+	decoder := xml.NewDecoder(bytes.NewReader(fileContent))
+	for {
+		// The line of the start of the next token, as counted by the decoder while reading.
+		line, _ := decoder.InputPos()
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Local != "trans-unit" {
+			continue
+		}
+		for _, attribute := range element.Attr {
+			if attribute.Name.Local == "id" {
+				lines[Key(attribute.Value)] = line
+			}
+		}
+	}
+
+	return lines, nil
+}
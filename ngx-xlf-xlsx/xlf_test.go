@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	. "common"
+)
+
+const testSourceXlf = `<?xml version="1.0" encoding="UTF-8" ?>
+<xliff version="1.2" xmlns="urn:oasis:names:tc:xliff:document:1.2">
+  <file source-language="en" datatype="plaintext" original="ng2.template">
+    <body>
+      <trans-unit id="bold" datatype="html">
+        <source>Click <x id="START_BOLD_TEXT" ctype="x-b" equiv-text="&lt;b&gt;" xid="1" disp="bold"/>here<x id="CLOSE_BOLD_TEXT" ctype="x-b" equiv-text="&lt;/b&gt;"/></source>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>
+`
+
+func TestXliff_Write_Placeholders(t *testing.T) {
+	dir := t.TempDir()
+	sourcePath := Path(filepath.Join(dir, "messages.xlf"))
+	err := os.WriteFile(string(sourcePath), []byte(testSourceXlf), defaultFilePermissions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sourceXlf, err := getPathXlf(sourcePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localePath := Path(filepath.Join(dir, "messages.fr.xlf"))
+	err = sourceXlf.write(localePath, KeyValueMap{"bold": "Cliquez ${{START_BOLD_TEXT}}ici${{CLOSE_BOLD_TEXT}}"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	localeXlf, err := getPathXlf(localePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transUnit := localeXlf.File.Body.TransUnits[0]
+	if transUnit.TargetStr != "Cliquez ${{START_BOLD_TEXT}}ici${{CLOSE_BOLD_TEXT}}" {
+		t.Errorf("Expected translation to be written, got %q", transUnit.TargetStr)
+	}
+	targetPlaceholders, err := extractPlaceholdersFromXMLString(transUnit.Target.InnerXML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(targetPlaceholders, sourceXlf.File.Body.TransUnits[0].X) {
+		t.Errorf("Expected placeholders of the target to have all the attributes of the source ones, %v, got %v",
+			sourceXlf.File.Body.TransUnits[0].X, targetPlaceholders)
+	}
+	if placeholder := targetPlaceholders[0]; placeholder.CType != "x-b" || placeholder.XID != "1" || len(placeholder.OtherAttrs) != 1 {
+		t.Errorf("Expected ctype, xid, and other attributes to be kept, got %#v", placeholder)
+	}
+}
+
+func TestXliff_Write_Escaping(t *testing.T) {
+	dir := t.TempDir()
+	sourcePath := Path(filepath.Join(dir, "messages.xlf"))
+	err := os.WriteFile(string(sourcePath), []byte(testSourceXlf), defaultFilePermissions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sourceXlf, err := getPathXlf(sourcePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Translations are text, so markup and entities are kept as typed.
+	translation := Value("Tom &amp; Jerry & <i>${{START_BOLD_TEXT}}co${{CLOSE_BOLD_TEXT}}</i>")
+	localePath := Path(filepath.Join(dir, "messages.fr.xlf"))
+	err = sourceXlf.write(localePath, KeyValueMap{"bold": translation}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	localeXlf, err := getPathXlf(localePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if targetStr := localeXlf.File.Body.TransUnits[0].TargetStr; targetStr != string(translation) {
+		t.Errorf("Expected translation to be read back as %q, got %q", translation, targetStr)
+	}
+}